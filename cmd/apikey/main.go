@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/auth"
+	"gopkg.in/yaml.v3"
+)
+
+//apikey generates a new API key, prints it once to stderr, and prints the entry
+//to append to the keys file to stdout. Only the hash of the key is stored
+func main() {
+	name := flag.String("name", "", "name of the client that uses the key")
+	scope := flag.String("scope", string(auth.ScopeRead), "routes the key has access to: read, write or admin")
+	rateLimit := flag.Float64("rate-limit", auth.DefaultRateLimit, "requests per second allowed to the key")
+	burst := flag.Int("burst", auth.DefaultBurst, "requests the key can make at once")
+	flag.Parse()
+
+	if *name == "" {
+		log.Fatal("-name is required")
+	}
+	if *scope != string(auth.ScopeRead) && *scope != string(auth.ScopeWrite) && *scope != string(auth.ScopeAdmin) {
+		log.Fatalf("unknown scope %q, use read, write or admin", *scope)
+	}
+	key, err := auth.GenerateKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "API key for %s, it won't be shown again: %s\n", *name, key)
+	entry := []auth.APIKey{{
+		Name:      *name,
+		Hash:      auth.HashKey(key),
+		Scope:     auth.Scope(*scope),
+		RateLimit: *rateLimit,
+		Burst:     *burst,
+	}}
+	if err := yaml.NewEncoder(os.Stdout).Encode(entry); err != nil {
+		log.Fatal(err)
+	}
+}
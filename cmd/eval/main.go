@@ -0,0 +1,11 @@
+package main
+
+import (
+	"os"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/delivery/eval"
+)
+
+func main() {
+	os.Exit(eval.Run(os.Args[1:], os.Stdout, os.Stderr))
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/config"
+	delivery "github.com/carlos-rodrigo/matching-app/pkg/delivery/grpc"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+)
+
+func main() {
+	options, err := config.Load(os.Args[0], os.Args[1:], os.Getenv, os.Stderr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if options.PrintConfig {
+		if err := options.Config.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	logger := options.Config.NewLogger(os.Stderr)
+
+	listener, err := net.Listen("tcp", options.Config.GRPCListenAddress)
+	if err != nil {
+		logger.Error("Server can't start", logging.Err(err))
+		os.Exit(1)
+	}
+	server, metricsHandler, err := delivery.GetServer(options.Config, logger)
+	if err != nil {
+		logger.Error("Server can't start", logging.Err(err))
+		os.Exit(1)
+	}
+
+	var metricsServer *http.Server
+	if address := options.Config.GRPCMetricsListenAddress; address != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metricsHandler)
+		metricsServer = &http.Server{Addr: address, Handler: mux}
+		go func() {
+			logger.Info("Exposing metrics", logging.F("address", address))
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("Metrics server failed", logging.Err(err))
+			}
+		}()
+	}
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+
+		logger.Info("Shutting down, waiting for in-flight requests")
+		deadline := time.AfterFunc(options.Config.ShutdownTimeout, func() {
+			logger.Warn("Shutdown deadline exceeded")
+			server.Stop()
+		})
+		server.GracefulStop()
+		deadline.Stop()
+		if metricsServer != nil {
+			metricsServer.Close()
+		}
+	}()
+
+	logger.Info("Listening", logging.F("address", options.Config.GRPCListenAddress))
+	if err := server.Serve(listener); err != nil {
+		logger.Error("Server failed", logging.Err(err))
+		os.Exit(1)
+	}
+	logger.Info("Server stopped")
+}
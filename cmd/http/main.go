@@ -1,13 +1,63 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/carlos-rodrigo/matching-app/pkg/config"
 	delivery "github.com/carlos-rodrigo/matching-app/pkg/delivery/http"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
 )
 
 func main() {
-	router := delivery.GetRouter()
-	log.Fatal(http.ListenAndServe(":8080", router))
+	options, err := config.Load(os.Args[0], os.Args[1:], os.Getenv, os.Stderr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if options.PrintConfig {
+		if err := options.Config.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	logger := options.Config.NewLogger(os.Stderr)
+
+	router, err := delivery.GetRouter(options.Config, logger)
+	if err != nil {
+		logger.Error("Server can't start", logging.Err(err))
+		os.Exit(1)
+	}
+	server := &http.Server{
+		Addr:         options.Config.ListenAddress,
+		Handler:      router,
+		ReadTimeout:  options.Config.ReadTimeout,
+		WriteTimeout: options.Config.WriteTimeout,
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+
+		logger.Info("Shutting down, waiting for in-flight requests")
+		ctx, cancel := context.WithTimeout(context.Background(), options.Config.ShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			logger.Warn("Shutdown deadline exceeded", logging.Err(err))
+		}
+		close(stopped)
+	}()
+
+	logger.Info("Listening", logging.F("address", options.Config.ListenAddress))
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		logger.Error("Server failed", logging.Err(err))
+		os.Exit(1)
+	}
+	<-stopped
+	logger.Info("Server stopped")
 }
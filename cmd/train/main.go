@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/storage"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+//train fits a ranking model to the feedback recorded by the HTTP server with logistic regression,
+//writes it to the output file and prints it to stdout. Servers load it with -ranking-model
+func main() {
+	feedbackPath := flag.String("feedback", "", "JSON lines file with the recorded feedback")
+	output := flag.String("output", "ranking_model.json", "file where the trained model is written")
+	epochs := flag.Int("epochs", matching.DefaultTrainingOptions.Epochs, "passes of gradient descent over the feedback")
+	learningRate := flag.Float64("learning-rate", matching.DefaultTrainingOptions.LearningRate, "step of gradient descent")
+	l2 := flag.Float64("l2", matching.DefaultTrainingOptions.L2, "penalty of large weights")
+	flag.Parse()
+
+	if *feedbackPath == "" {
+		log.Fatal("-feedback is required")
+	}
+	if *epochs <= 0 || *learningRate <= 0 || *l2 < 0 {
+		log.Fatal("-epochs and -learning-rate must be greater than 0, and -l2 can't be negative")
+	}
+	feedback, err := storage.LoadFeedback(*feedbackPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	model, err := matching.TrainRankingModel(feedback, matching.TrainingOptions{
+		Epochs:       *epochs,
+		LearningRate: *learningRate,
+		L2:           *l2,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := storage.SaveRankingModel(*output, model); err != nil {
+		log.Fatal(err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(model); err != nil {
+		log.Fatal(err)
+	}
+}
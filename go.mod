@@ -4,6 +4,11 @@ go 1.15
 
 require (
 	github.com/julienschmidt/httprouter v1.3.0
-	github.com/stretchr/testify v1.6.1
+	github.com/prometheus/client_golang v1.8.0
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/time v0.0.0-20200416051211-89c76fbcd5d1
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
 	googlemaps.github.io/maps v1.2.3
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//ErrUnknownKey is returned when an API key isn't registered
+var ErrUnknownKey = errors.New("Unknown API key")
+
+//DefaultRateLimit is the amount of requests per second allowed to a key without rateLimit
+const DefaultRateLimit = 10.0
+
+//DefaultBurst is the amount of requests a key without burst can make at once
+const DefaultBurst = 20
+
+//Scope represents the routes an API key has access to
+type Scope string
+
+const (
+	//ScopeRead gives access to the matching routes
+	ScopeRead Scope = "read"
+	//ScopeWrite gives access to the matching routes and to the ones that record data, like POST /feedback
+	ScopeWrite Scope = "write"
+	//ScopeAdmin gives access to every route, including the operational ones like /metrics
+	ScopeAdmin Scope = "admin"
+)
+
+//Allows returns true when the scope gives access to the routes of the required scope
+func (s Scope) Allows(required Scope) bool {
+	return s == ScopeAdmin || s == required || (s == ScopeWrite && required == ScopeRead)
+}
+
+//APIKey represents a registered API key. Only the SHA-256 hash of the key is stored
+type APIKey struct {
+	Name      string  `yaml:"name"`
+	Hash      string  `yaml:"hash"`
+	Scope     Scope   `yaml:"scope"`
+	RateLimit float64 `yaml:"rateLimit"`
+	Burst     int     `yaml:"burst"`
+}
+
+//KeyStore represents the registered API keys
+type KeyStore interface {
+	Find(key string) (APIKey, error)
+}
+
+//FileKeyStore represents a KeyStore read from a YAML file
+type FileKeyStore struct {
+	keys map[string]APIKey
+}
+
+//Find returns the APIKey registered with the hash of the given key, or ErrUnknownKey
+func (s *FileKeyStore) Find(key string) (APIKey, error) {
+	apiKey, ok := s.keys[HashKey(key)]
+	if !ok {
+		return APIKey{}, ErrUnknownKey
+	}
+	return apiKey, nil
+}
+
+//HashKey returns the hex encoded SHA-256 hash of an API key, as it's stored in the keys file
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+//GenerateKey returns a new random API key
+func GenerateKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+//NewFileKeyStore returns a FileKeyStore with the keys of the YAML file at path.
+//It returns an error describing every invalid key found
+func NewFileKeyStore(path string) (*FileKeyStore, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := struct {
+		Keys []APIKey `yaml:"keys"`
+	}{}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	store := &FileKeyStore{keys: map[string]APIKey{}}
+	names := map[string]bool{}
+	problems := []string{}
+	for i, key := range file.Keys {
+		key.Hash = strings.ToLower(key.Hash)
+		if key.RateLimit == 0 {
+			key.RateLimit = DefaultRateLimit
+		}
+		if key.Burst == 0 {
+			key.Burst = DefaultBurst
+		}
+		keyProblems := validate(key, names)
+		if _, repeated := store.keys[key.Hash]; repeated {
+			keyProblems = append(keyProblems, "hash is repeated")
+		}
+		names[key.Name] = true
+		if len(keyProblems) > 0 {
+			problems = append(problems, fmt.Sprintf("key %d: %s", i+1, strings.Join(keyProblems, ", ")))
+			continue
+		}
+		store.keys[key.Hash] = key
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s: invalid keys: %s", path, strings.Join(problems, "; "))
+	}
+	return store, nil
+}
+
+func validate(key APIKey, names map[string]bool) []string {
+	problems := []string{}
+	if key.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if names[key.Name] {
+		problems = append(problems, "name is repeated")
+	}
+	if decoded, err := hex.DecodeString(key.Hash); err != nil || len(decoded) != sha256.Size {
+		problems = append(problems, "hash must be a hex encoded SHA-256 hash")
+	}
+	if key.Scope != ScopeRead && key.Scope != ScopeWrite && key.Scope != ScopeAdmin {
+		problems = append(problems, fmt.Sprintf("scope must be %s, %s or %s", ScopeRead, ScopeWrite, ScopeAdmin))
+	}
+	if key.RateLimit < 0 || key.Burst < 0 {
+		problems = append(problems, "rateLimit and burst can't be negative")
+	}
+	return problems
+}
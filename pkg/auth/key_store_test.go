@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileKeyStore(t *testing.T) {
+	t.Run("Given a keys file, When a registered key is looked for, Then must return it with the default limits when they are missing", func(t *testing.T) {
+		store, err := NewFileKeyStore("testdata/keys.yaml")
+		assert.Nil(t, err)
+
+		analyst, errAnalyst := store.Find("read-key")
+		operator, errOperator := store.Find("admin-key")
+
+		assert.Nil(t, errAnalyst)
+		assert.Equal(t, "analyst", analyst.Name)
+		assert.Equal(t, ScopeRead, analyst.Scope)
+		assert.Equal(t, 1.0, analyst.RateLimit)
+		assert.Equal(t, 2, analyst.Burst)
+		assert.Nil(t, errOperator)
+		assert.Equal(t, ScopeAdmin, operator.Scope)
+		assert.Equal(t, DefaultRateLimit, operator.RateLimit)
+		assert.Equal(t, DefaultBurst, operator.Burst)
+	})
+	t.Run("Given a keys file, When an unknown key is looked for, Then must return ErrUnknownKey", func(t *testing.T) {
+		store, _ := NewFileKeyStore("testdata/keys.yaml")
+
+		_, err := store.Find("guessed-key")
+
+		assert.Equal(t, ErrUnknownKey, err)
+	})
+	t.Run("Given a keys file with invalid keys, When it's read, Then must return every problem found", func(t *testing.T) {
+		_, err := NewFileKeyStore("testdata/invalid_keys.yaml")
+
+		assert.Contains(t, err.Error(), "key 1: hash must be a hex encoded SHA-256 hash")
+		assert.Contains(t, err.Error(), "key 2: name is repeated, scope must be read, write or admin")
+	})
+	t.Run("Given a generated key, When it's hashed, Then must be found by its hash", func(t *testing.T) {
+		key, err := GenerateKey()
+		store := &FileKeyStore{keys: map[string]APIKey{HashKey(key): APIKey{Name: "generated"}}}
+
+		found, errFind := store.Find(key)
+
+		assert.Nil(t, err)
+		assert.Nil(t, errFind)
+		assert.Equal(t, "generated", found.Name)
+	})
+}
+
+func TestScope(t *testing.T) {
+	t.Run("Given a read scope, When admin routes are checked, Then must not allow them", func(t *testing.T) {
+		assert.True(t, ScopeRead.Allows(ScopeRead))
+		assert.False(t, ScopeRead.Allows(ScopeWrite))
+		assert.False(t, ScopeRead.Allows(ScopeAdmin))
+	})
+	t.Run("Given a write scope, When routes are checked, Then must allow read and write routes but not admin ones", func(t *testing.T) {
+		assert.True(t, ScopeWrite.Allows(ScopeRead))
+		assert.True(t, ScopeWrite.Allows(ScopeWrite))
+		assert.False(t, ScopeWrite.Allows(ScopeAdmin))
+	})
+	t.Run("Given an admin scope, When any route is checked, Then must allow it", func(t *testing.T) {
+		assert.True(t, ScopeAdmin.Allows(ScopeRead))
+		assert.True(t, ScopeAdmin.Allows(ScopeWrite))
+		assert.True(t, ScopeAdmin.Allows(ScopeAdmin))
+	})
+}
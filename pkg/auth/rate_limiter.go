@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+//RateLimiter enforces a token bucket per API key
+type RateLimiter struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	now      func() time.Time
+}
+
+//Allow takes a token from the bucket of the key. When the bucket is empty it returns false,
+//and how long the client must wait until a token is available
+func (l *RateLimiter) Allow(key APIKey) (bool, time.Duration) {
+	l.mu.Lock()
+	limiter, ok := l.limiters[key.Name]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(key.RateLimit), key.Burst)
+		l.limiters[key.Name] = limiter
+	}
+	l.mu.Unlock()
+
+	now := l.now()
+	reservation := limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+//NewRateLimiter returns a RateLimiter that builds the bucket of every key on its first request
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		limiters: map[string]*rate.Limiter{},
+		now:      time.Now,
+	}
+}
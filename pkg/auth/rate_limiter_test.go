@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	key := APIKey{Name: "analyst", RateLimit: 1, Burst: 2}
+
+	t.Run("Given a key with a burst of 2, When it makes 3 requests at once, Then the third must wait for a token", func(t *testing.T) {
+		limiter := NewRateLimiter()
+		now := time.Now()
+		limiter.now = func() time.Time { return now }
+
+		first, _ := limiter.Allow(key)
+		second, _ := limiter.Allow(key)
+		third, retryAfter := limiter.Allow(key)
+
+		assert.True(t, first)
+		assert.True(t, second)
+		assert.False(t, third)
+		assert.Equal(t, time.Second, retryAfter)
+	})
+	t.Run("Given a key out of tokens, When it waits for its rate, Then must be allowed again", func(t *testing.T) {
+		limiter := NewRateLimiter()
+		now := time.Now()
+		limiter.now = func() time.Time { return now }
+		limiter.Allow(key)
+		limiter.Allow(key)
+
+		now = now.Add(time.Second)
+		allowed, _ := limiter.Allow(key)
+
+		assert.True(t, allowed)
+	})
+	t.Run("Given two keys, When one runs out of tokens, Then the other must be allowed", func(t *testing.T) {
+		limiter := NewRateLimiter()
+		limiter.Allow(key)
+		limiter.Allow(key)
+
+		allowed, _ := limiter.Allow(APIKey{Name: "operator", RateLimit: 1, Burst: 1})
+
+		assert.True(t, allowed)
+	})
+}
@@ -0,0 +1,309 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"gopkg.in/yaml.v3"
+)
+
+//EnvPrefix is the prefix of every environment variable read by the configuration
+const EnvPrefix = "MATCHING_"
+
+var geocoders = []string{"google", "csv"}
+var logLevels = []string{"debug", "info", "warn", "error"}
+var logFormats = []string{string(logging.TextFormat), string(logging.JSONFormat)}
+
+//Config represents the settings of the application servers
+type Config struct {
+	ListenAddress            string        `yaml:"listenAddress"`
+	GRPCListenAddress        string        `yaml:"grpcListenAddress"`
+	GRPCMetricsListenAddress string        `yaml:"grpcMetricsListenAddress"`
+	DataSource               string        `yaml:"dataSource"`
+	Geocoder                 string        `yaml:"geocoder"`
+	MapsAPIKey               string        `yaml:"mapsApiKey"`
+	MaxDistance              float64       `yaml:"maxDistance"`
+	ReadTimeout              time.Duration `yaml:"readTimeout"`
+	WriteTimeout             time.Duration `yaml:"writeTimeout"`
+	ShutdownTimeout          time.Duration `yaml:"shutdownTimeout"`
+	LogLevel                 string        `yaml:"logLevel"`
+	LogFormat                string        `yaml:"logFormat"`
+	LogPII                   bool          `yaml:"logPii"`
+	AuthKeysFile             string        `yaml:"authKeysFile"`
+	JobTitleTaxonomy         string        `yaml:"jobTitleTaxonomy"`
+	RankingModel             string        `yaml:"rankingModel"`
+	Relevance                bool          `yaml:"relevance"`
+	FeedbackFile             string        `yaml:"feedbackFile"`
+	Experiment               string        `yaml:"experiment"`
+	Allocate                 bool          `yaml:"allocate"`
+	ParticipationFile        string        `yaml:"participationFile"`
+}
+
+//Default returns the Config used when no flag, environment variable or file changes it
+func Default() Config {
+	return Config{
+		ListenAddress:     ":8080",
+		GRPCListenAddress: ":9090",
+		DataSource:        "./respondents_data_test.csv",
+		Geocoder:          "csv",
+		MaxDistance:       100,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		ShutdownTimeout:   20 * time.Second,
+		LogLevel:          "info",
+		LogFormat:         "text",
+	}
+}
+
+//Options represents the result of loading the configuration from the command line
+type Options struct {
+	Config      Config
+	PrintConfig bool
+}
+
+//Load builds the Config from its defaults, then an optional YAML file, then environment
+//variables and finally the command line flags, each of them overriding the previous ones
+func Load(name string, args []string, getenv func(string) string, output io.Writer) (Options, error) {
+	options := Options{Config: Default()}
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(output)
+	configPath := flags.String("config", getenv(EnvPrefix+"CONFIG"), "optional YAML configuration file, also read from "+EnvPrefix+"CONFIG")
+	flags.BoolVar(&options.PrintConfig, "print-config", false, "print the resulting configuration and exit")
+	flagValues := Default()
+	flags.StringVar(&flagValues.ListenAddress, "listen-address", flagValues.ListenAddress, "address where the HTTP server listens")
+	flags.StringVar(&flagValues.GRPCListenAddress, "grpc-listen-address", flagValues.GRPCListenAddress, "address where the gRPC server listens")
+	flags.StringVar(&flagValues.GRPCMetricsListenAddress, "grpc-metrics-listen-address", flagValues.GRPCMetricsListenAddress, "address where the gRPC server exposes /metrics, they aren't exposed when it's empty")
+	flags.StringVar(&flagValues.DataSource, "data-source", flagValues.DataSource, "csv file with the participants")
+	flags.StringVar(&flagValues.Geocoder, "geocoder", flagValues.Geocoder, "how participant addresses are resolved: google or csv")
+	flags.StringVar(&flagValues.MapsAPIKey, "maps-api-key", "", "Google Maps API key, required by the google geocoder")
+	flags.Float64Var(&flagValues.MaxDistance, "max-distance", flagValues.MaxDistance, "default radius in km around project cities")
+	flags.DurationVar(&flagValues.ReadTimeout, "read-timeout", flagValues.ReadTimeout, "maximum duration to read a request")
+	flags.DurationVar(&flagValues.WriteTimeout, "write-timeout", flagValues.WriteTimeout, "maximum duration to write a response")
+	flags.DurationVar(&flagValues.ShutdownTimeout, "shutdown-timeout", flagValues.ShutdownTimeout, "maximum duration to finish in-flight requests on shutdown")
+	flags.StringVar(&flagValues.LogLevel, "log-level", flagValues.LogLevel, "minimum log level: debug, info, warn or error")
+	flags.StringVar(&flagValues.LogFormat, "log-format", flagValues.LogFormat, "log entries format: text or json")
+	flags.BoolVar(&flagValues.LogPII, "log-pii", flagValues.LogPII, "log participant names and coordinates instead of redacting them")
+	flags.StringVar(&flagValues.AuthKeysFile, "auth-keys-file", flagValues.AuthKeysFile, "YAML file with the hashed API keys, routes are open when it's empty")
+	flags.StringVar(&flagValues.JobTitleTaxonomy, "job-title-taxonomy", flagValues.JobTitleTaxonomy, "YAML file with the job title roles and synonyms, the built-in taxonomy is used when it's empty")
+	flags.StringVar(&flagValues.RankingModel, "ranking-model", flagValues.RankingModel, "JSON file with the ranking model trained from feedback, the default weights are used when it's empty")
+	flags.BoolVar(&flagValues.Relevance, "relevance", flagValues.Relevance, "rank participants by the BM25 relevance of their job title and industries for the project terms")
+	flags.StringVar(&flagValues.FeedbackFile, "feedback-file", flagValues.FeedbackFile, "file where recruiter feedback is appended, /feedback is disabled when it's empty")
+	flags.StringVar(&flagValues.Experiment, "experiment", flagValues.Experiment, "YAML file with the scoring variants compared on live traffic, every project uses the same scoring when it's empty")
+	flags.StringVar(&flagValues.ParticipationFile, "participation-file", flagValues.ParticipationFile, "JSONL file with the participation history of participants, shared by the servers, project fatigue rules are ignored and /participations is disabled when it's empty")
+	flags.BoolVar(&flagValues.Allocate, "allocate", flagValues.Allocate, "assign every participant to one project city in range, filling the city capacities")
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+
+	if *configPath != "" {
+		if err := readFile(*configPath, &options.Config); err != nil {
+			return options, err
+		}
+	}
+	if err := readEnv(getenv, &options.Config); err != nil {
+		return options, err
+	}
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "listen-address":
+			options.Config.ListenAddress = flagValues.ListenAddress
+		case "grpc-listen-address":
+			options.Config.GRPCListenAddress = flagValues.GRPCListenAddress
+		case "grpc-metrics-listen-address":
+			options.Config.GRPCMetricsListenAddress = flagValues.GRPCMetricsListenAddress
+		case "data-source":
+			options.Config.DataSource = flagValues.DataSource
+		case "geocoder":
+			options.Config.Geocoder = flagValues.Geocoder
+		case "maps-api-key":
+			options.Config.MapsAPIKey = flagValues.MapsAPIKey
+		case "max-distance":
+			options.Config.MaxDistance = flagValues.MaxDistance
+		case "read-timeout":
+			options.Config.ReadTimeout = flagValues.ReadTimeout
+		case "write-timeout":
+			options.Config.WriteTimeout = flagValues.WriteTimeout
+		case "shutdown-timeout":
+			options.Config.ShutdownTimeout = flagValues.ShutdownTimeout
+		case "log-level":
+			options.Config.LogLevel = flagValues.LogLevel
+		case "log-format":
+			options.Config.LogFormat = flagValues.LogFormat
+		case "log-pii":
+			options.Config.LogPII = flagValues.LogPII
+		case "auth-keys-file":
+			options.Config.AuthKeysFile = flagValues.AuthKeysFile
+		case "job-title-taxonomy":
+			options.Config.JobTitleTaxonomy = flagValues.JobTitleTaxonomy
+		case "ranking-model":
+			options.Config.RankingModel = flagValues.RankingModel
+		case "relevance":
+			options.Config.Relevance = flagValues.Relevance
+		case "feedback-file":
+			options.Config.FeedbackFile = flagValues.FeedbackFile
+		case "experiment":
+			options.Config.Experiment = flagValues.Experiment
+		case "participation-file":
+			options.Config.ParticipationFile = flagValues.ParticipationFile
+		case "allocate":
+			options.Config.Allocate = flagValues.Allocate
+		}
+	})
+
+	return options, options.Config.Validate()
+}
+
+func readFile(path string, c *Config) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(content, c); err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	return nil
+}
+
+func readEnv(getenv func(string) string, c *Config) error {
+	texts := map[string]*string{
+		"LISTEN_ADDRESS":              &c.ListenAddress,
+		"GRPC_LISTEN_ADDRESS":         &c.GRPCListenAddress,
+		"GRPC_METRICS_LISTEN_ADDRESS": &c.GRPCMetricsListenAddress,
+		"DATA_SOURCE":                 &c.DataSource,
+		"GEOCODER":                    &c.Geocoder,
+		"MAPS_API_KEY":                &c.MapsAPIKey,
+		"LOG_LEVEL":                   &c.LogLevel,
+		"LOG_FORMAT":                  &c.LogFormat,
+		"AUTH_KEYS_FILE":              &c.AuthKeysFile,
+		"JOB_TITLE_TAXONOMY":          &c.JobTitleTaxonomy,
+		"RANKING_MODEL":               &c.RankingModel,
+		"FEEDBACK_FILE":               &c.FeedbackFile,
+		"EXPERIMENT":                  &c.Experiment,
+		"PARTICIPATION_FILE":          &c.ParticipationFile,
+	}
+	for name, value := range texts {
+		if env := getenv(EnvPrefix + name); env != "" {
+			*value = env
+		}
+	}
+
+	durations := map[string]*time.Duration{
+		"READ_TIMEOUT":     &c.ReadTimeout,
+		"WRITE_TIMEOUT":    &c.WriteTimeout,
+		"SHUTDOWN_TIMEOUT": &c.ShutdownTimeout,
+	}
+	for name, value := range durations {
+		if env := getenv(EnvPrefix + name); env != "" {
+			duration, err := time.ParseDuration(env)
+			if err != nil {
+				return fmt.Errorf("%s%s: %s", EnvPrefix, name, err)
+			}
+			*value = duration
+		}
+	}
+
+	if env := getenv(EnvPrefix + "MAX_DISTANCE"); env != "" {
+		maxDistance, err := strconv.ParseFloat(env, 64)
+		if err != nil {
+			return fmt.Errorf("%sMAX_DISTANCE: %s", EnvPrefix, err)
+		}
+		c.MaxDistance = maxDistance
+	}
+
+	if env := getenv(EnvPrefix + "LOG_PII"); env != "" {
+		logPII, err := strconv.ParseBool(env)
+		if err != nil {
+			return fmt.Errorf("%sLOG_PII: %s", EnvPrefix, err)
+		}
+		c.LogPII = logPII
+	}
+
+	if env := getenv(EnvPrefix + "ALLOCATE"); env != "" {
+		allocate, err := strconv.ParseBool(env)
+		if err != nil {
+			return fmt.Errorf("%sALLOCATE: %s", EnvPrefix, err)
+		}
+		c.Allocate = allocate
+	}
+
+	if env := getenv(EnvPrefix + "RELEVANCE"); env != "" {
+		relevance, err := strconv.ParseBool(env)
+		if err != nil {
+			return fmt.Errorf("%sRELEVANCE: %s", EnvPrefix, err)
+		}
+		c.Relevance = relevance
+	}
+	return nil
+}
+
+//Validate returns an error describing every invalid setting of the Config, or nil when it's valid
+func (c Config) Validate() error {
+	problems := []string{}
+	if c.ListenAddress == "" {
+		problems = append(problems, "listenAddress is required")
+	}
+	if c.GRPCListenAddress == "" {
+		problems = append(problems, "grpcListenAddress is required")
+	}
+	if c.DataSource == "" {
+		problems = append(problems, "dataSource is required")
+	}
+	if !contains(geocoders, c.Geocoder) {
+		problems = append(problems, fmt.Sprintf("geocoder must be one of %s", strings.Join(geocoders, ", ")))
+	}
+	if c.Geocoder == "google" && c.MapsAPIKey == "" {
+		problems = append(problems, "mapsApiKey is required by the google geocoder")
+	}
+	if c.MaxDistance <= 0 {
+		problems = append(problems, "maxDistance must be greater than 0")
+	}
+	if c.ReadTimeout < 0 || c.WriteTimeout < 0 || c.ShutdownTimeout < 0 {
+		problems = append(problems, "timeouts can't be negative")
+	}
+	if !contains(logLevels, c.LogLevel) {
+		problems = append(problems, fmt.Sprintf("logLevel must be one of %s", strings.Join(logLevels, ", ")))
+	}
+	if !contains(logFormats, c.LogFormat) {
+		problems = append(problems, fmt.Sprintf("logFormat must be one of %s", strings.Join(logFormats, ", ")))
+	}
+
+	if len(problems) > 0 {
+		return errors.New("Invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+//NewLogger returns a Logger that writes to w with the log settings of the Config
+func (c Config) NewLogger(w io.Writer) logging.Logger {
+	level, _ := logging.ParseLevel(c.LogLevel)
+	return logging.New(w, logging.Options{
+		Level:   level,
+		Format:  logging.Format(c.LogFormat),
+		KeepPII: c.LogPII,
+	})
+}
+
+//Print writes the Config as YAML, hiding credentials
+func (c Config) Print(w io.Writer) error {
+	if c.MapsAPIKey != "" {
+		c.MapsAPIKey = "********"
+	}
+	return yaml.NewEncoder(w).Encode(c)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func env(values map[string]string) func(string) string {
+	return func(name string) string {
+		return values[name]
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Given no settings, When config is loaded, Then must use the defaults", func(t *testing.T) {
+		options, err := Load("test", []string{}, env(map[string]string{"MATCHING_MAPS_API_KEY": "key"}), ioutil.Discard)
+
+		expected := Default()
+		expected.MapsAPIKey = "key"
+		assert.Nil(t, err)
+		assert.Equal(t, expected, options.Config)
+		assert.False(t, options.PrintConfig)
+	})
+	t.Run("Given a config file, environment variables and flags, When config is loaded, Then flags must override environment variables and environment variables must override the file", func(t *testing.T) {
+		options, err := Load("test", []string{"-config", "testdata/config.yaml", "-max-distance", "25"}, env(map[string]string{
+			"MATCHING_LISTEN_ADDRESS":     ":9000",
+			"MATCHING_MAX_DISTANCE":       "75",
+			"MATCHING_LOG_PII":            "true",
+			"MATCHING_ALLOCATE":           "true",
+			"MATCHING_RELEVANCE":          "true",
+			"MATCHING_PARTICIPATION_FILE": "participations.jsonl",
+		}), ioutil.Discard)
+
+		assert.Nil(t, err)
+		assert.Equal(t, ":9000", options.Config.ListenAddress)
+		assert.Equal(t, "./participants.csv", options.Config.DataSource)
+		assert.Equal(t, "csv", options.Config.Geocoder)
+		assert.Equal(t, 25.0, options.Config.MaxDistance)
+		assert.Equal(t, 5*time.Second, options.Config.ReadTimeout)
+		assert.Equal(t, 30*time.Second, options.Config.WriteTimeout)
+		assert.Equal(t, "debug", options.Config.LogLevel)
+		assert.Equal(t, "json", options.Config.LogFormat)
+		assert.True(t, options.Config.LogPII)
+		assert.True(t, options.Config.Allocate)
+		assert.True(t, options.Config.Relevance)
+		assert.Equal(t, "participations.jsonl", options.Config.ParticipationFile)
+	})
+	t.Run("Given invalid settings, When config is loaded, Then must return every problem found", func(t *testing.T) {
+		_, err := Load("test", []string{"-geocoder", "google", "-max-distance", "0", "-log-level", "verbose", "-log-format", "xml"}, env(map[string]string{}), ioutil.Discard)
+
+		assert.Equal(t, "Invalid configuration: mapsApiKey is required by the google geocoder; maxDistance must be greater than 0; logLevel must be one of debug, info, warn, error; logFormat must be one of text, json", err.Error())
+	})
+	t.Run("Given an invalid environment variable, When config is loaded, Then must return an error naming the variable", func(t *testing.T) {
+		_, err := Load("test", []string{}, env(map[string]string{"MATCHING_READ_TIMEOUT": "soon"}), ioutil.Discard)
+
+		assert.Contains(t, err.Error(), "MATCHING_READ_TIMEOUT")
+	})
+	t.Run("Given the print-config flag, When config is printed, Then must hide the Maps API key", func(t *testing.T) {
+		options, err := Load("test", []string{"-print-config", "-maps-api-key", "secret"}, env(map[string]string{}), ioutil.Discard)
+		output := bytes.Buffer{}
+
+		errPrint := options.Config.Print(&output)
+
+		assert.Nil(t, err)
+		assert.Nil(t, errPrint)
+		assert.True(t, options.PrintConfig)
+		assert.NotContains(t, output.String(), "secret")
+		assert.Contains(t, output.String(), "readTimeout: 10s")
+	})
+}
@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/config"
+	"github.com/carlos-rodrigo/matching-app/pkg/delivery/export"
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/storage"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+const (
+	exitOK             = 0
+	exitFailure        = 1
+	exitUsage          = 2
+	exitInvalidProject = 3
+)
+
+var formats = []string{"table", "json", "csv"}
+var geocoders = []string{"csv", "google"}
+
+type projectFile struct {
+	Path    string
+	Project matching.Project
+}
+
+type projectResults struct {
+	Project      string                         `json:"project"`
+	Total        int                            `json:"total"`
+	Participants []matching.MatchingParticipant `json:"participants"`
+}
+
+//Run executes the command line matcher with the given arguments and returns the exit code of the process.
+//It returns 2 for usage errors, 3 when a project is invalid, and 1 when projects can't be matched
+func Run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("matching", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	projectPath := flags.String("project", "", "project JSON file, or a directory of project JSON files")
+	participantsPath := flags.String("participants", "", "csv file with the participants")
+	geocoder := flags.String("geocoder", "csv", "how participant addresses are resolved: csv uses the city column offline, google calls Google Geocoding API")
+	format := flags.String("format", "table", "output format: table, json or csv")
+	limit := flags.Int("limit", 0, "maximum amount of participants printed per project, 0 prints all of them")
+	jobTitleTaxonomy := flags.String("job-title-taxonomy", "", "YAML file with the job title roles and synonyms, the built-in taxonomy is used when it's empty")
+	rankingModel := flags.String("ranking-model", "", "JSON file with the ranking model trained from feedback, the default weights are used when it's empty")
+	relevance := flags.Bool("relevance", false, "rank participants by the BM25 relevance of their job title and industries for the project terms")
+	allocate := flags.Bool("allocate", false, "assign every participant to one project city in range, filling the city capacities")
+	participationFile := flags.String("participation-file", "", "JSONL file with the participation history of participants, project fatigue rules are ignored when it's empty")
+	logLevel := flags.String("log-level", "warn", "minimum level of the log entries written to stderr: debug, info, warn or error")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *projectPath == "" || *participantsPath == "" {
+		fmt.Fprintln(stderr, "-project and -participants are required")
+		flags.Usage()
+		return exitUsage
+	}
+	if !contains(formats, *format) {
+		fmt.Fprintf(stderr, "unknown format %q, use one of %s\n", *format, strings.Join(formats, ", "))
+		return exitUsage
+	}
+	if !contains(geocoders, *geocoder) {
+		fmt.Fprintf(stderr, "unknown geocoder %q, use one of %s\n", *geocoder, strings.Join(geocoders, ", "))
+		return exitUsage
+	}
+
+	level, err := logging.ParseLevel(*logLevel)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	logger := logging.New(stderr, logging.Options{Level: level, Format: logging.TextFormat})
+
+	projects, err := readProjects(*projectPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitInvalidProject
+	}
+
+	repository, err := newRepository(*participantsPath, *geocoder, logger)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+	scoreOptions, err := storage.LoadScoreOptions(config.Config{JobTitleTaxonomy: *jobTitleTaxonomy, RankingModel: *rankingModel, Relevance: *relevance}, repository)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	actionOptions := []matching.ActionOption{matching.WithLogger(logger)}
+	if *allocate {
+		actionOptions = append(actionOptions, matching.WithAllocation(matching.DefaultAllocationCosts))
+	}
+	if *participationFile != "" {
+		history, err := storage.NewJSONLinesParticipationRepository(*participationFile)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitUsage
+		}
+		actionOptions = append(actionOptions, matching.WithParticipationHistory(history))
+	}
+	action := matching.NewMatchingParticipantsAction(repository, matching.NewDistanceService(), matching.NewScoreService(scoreOptions...), actionOptions...)
+
+	var rows export.ProjectsResultsWriter
+	if *format == "csv" {
+		rows = export.NewCsvProjectsResultsWriter(stdout)
+	}
+	exitCode := exitOK
+	for i, project := range projects {
+		ctx := logging.WithRequestID(context.Background(), project.Path)
+		participants, err := action.GetMatchingParticipantsForProject(ctx, project.Project)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", project.Path, err)
+			exitCode = exitFailure
+			continue
+		}
+		results := projectResults{
+			Project:      project.Path,
+			Total:        len(participants),
+			Participants: participants,
+		}
+		if *limit > 0 && len(participants) > *limit {
+			results.Participants = participants[:*limit]
+		}
+		if err := writeResults(stdout, *format, results, i > 0, rows); err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", project.Path, err)
+			return exitFailure
+		}
+	}
+	if rows != nil {
+		if err := rows.Close(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
+	}
+
+	return exitCode
+}
+
+func newRepository(participantsPath string, geocoder string, logger logging.Logger) (*storage.CsvParticipantRepository, error) {
+	if geocoder == "google" {
+		googleGeocoder, err := storage.NewGoogleMapsGeocoder(os.Getenv(storage.MapsAPIKeyEnv))
+		if err != nil {
+			return nil, err
+		}
+		return storage.NewCsvParticipantsRepositoryWithGeocoder(participantsPath, googleGeocoder, logger)
+	}
+	return storage.NewCsvParticipantsRepositoryWithGeocoder(participantsPath, storage.NewCsvCityGeocoder(), logger)
+}
+
+//readProjects reads and validates every project before matching any of them,
+//so a batch is not partially run when one of its projects is invalid
+func readProjects(path string) ([]projectFile, error) {
+	paths, err := projectPaths(path)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := []projectFile{}
+	problems := []string{}
+	for _, projectPath := range paths {
+		project, err := readProject(projectPath)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", projectPath, err))
+			continue
+		}
+		projects = append(projects, projectFile{Path: projectPath, Project: project})
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(problems, "\n"))
+	}
+	return projects, nil
+}
+
+func projectPaths(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%s: there are no project JSON files", path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func readProject(path string) (matching.Project, error) {
+	project := matching.Project{}
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return project, err
+	}
+	if err := json.Unmarshal(body, &project); err != nil {
+		return project, err
+	}
+	return project, project.Validate()
+}
+
+//writeResults writes the results of a project in the format. CSV rows of every project are written with rows,
+//which is closed once every project is written
+func writeResults(w io.Writer, format string, results projectResults, separate bool, rows export.ProjectsResultsWriter) error {
+	if format == "json" {
+		return json.NewEncoder(w).Encode(results)
+	}
+	if format == "csv" {
+		for _, participant := range results.Participants {
+			if err := rows.Write(results.Project, participant); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if separate {
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "%s: %d matching participants\n", results.Project, results.Total)
+	return export.WriteAll(export.NewTableResultsWriter(w), results.Participants)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
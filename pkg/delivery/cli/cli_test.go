@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const participantsPath = "../../infrastructure/storage/respondents_data_test.csv"
+
+func TestRun(t *testing.T) {
+	t.Run("Given a directory of projects, When the matcher runs with json format, Then must print the results of every project", func(t *testing.T) {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+		exitCode := Run([]string{"-project", "testdata/projects", "-participants", participantsPath, "-format", "json", "-limit", "2"}, &stdout, &stderr)
+
+		assert.Equal(t, exitOK, exitCode, stderr.String())
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		assert.Equal(t, 2, len(lines))
+		results := projectResults{}
+		assert.Nil(t, json.Unmarshal([]byte(lines[1]), &results))
+		assert.Equal(t, "testdata/projects/new_york.json", results.Project)
+		assert.Equal(t, 2, len(results.Participants))
+		assert.True(t, results.Total > 2)
+		assert.True(t, results.Participants[0].Score >= results.Participants[1].Score)
+	})
+	t.Run("Given a project, When the matcher runs with csv format, Then must print a csv header and rows", func(t *testing.T) {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+		exitCode := Run([]string{"-project", "testdata/projects/brooklyn.json", "-participants", participantsPath, "-format", "csv"}, &stdout, &stderr)
+
+		assert.Equal(t, exitOK, exitCode, stderr.String())
+		assert.True(t, strings.HasPrefix(stdout.String(), "project,participant_id,name,city"))
+	})
+	t.Run("Given a directory of projects, When the matcher runs with csv format, Then must print a single csv with the project of every row", func(t *testing.T) {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+		exitCode := Run([]string{"-project", "testdata/projects", "-participants", participantsPath, "-format", "csv", "-limit", "1"}, &stdout, &stderr)
+
+		assert.Equal(t, exitOK, exitCode, stderr.String())
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		assert.Equal(t, 3, len(lines))
+		assert.True(t, strings.HasPrefix(lines[0], "project,participant_id"))
+		assert.True(t, strings.HasPrefix(lines[1], "testdata/projects/brooklyn.json,"))
+		assert.True(t, strings.HasPrefix(lines[2], "testdata/projects/new_york.json,"))
+	})
+	t.Run("Given an invalid project, When the matcher runs, Then must report the problems and exit with the invalid project code", func(t *testing.T) {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+		exitCode := Run([]string{"-project", "testdata/invalid", "-participants", participantsPath}, &stdout, &stderr)
+
+		assert.Equal(t, exitInvalidProject, exitCode)
+		assert.Contains(t, stderr.String(), "at least one city is required")
+		assert.Equal(t, "", stdout.String())
+	})
+	t.Run("Given an unknown format, When the matcher runs, Then must exit with the usage code", func(t *testing.T) {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+		exitCode := Run([]string{"-project", "testdata/projects", "-participants", participantsPath, "-format", "xml"}, &stdout, &stderr)
+
+		assert.Equal(t, exitUsage, exitCode)
+	})
+}
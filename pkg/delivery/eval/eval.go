@@ -0,0 +1,331 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/config"
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/storage"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+const (
+	exitOK             = 0
+	exitFailure        = 1
+	exitUsage          = 2
+	exitInvalidProject = 3
+)
+
+const defaultScorer = "default"
+
+var formats = []string{"table", "json"}
+
+//labelledProject is a project with the participants a recruiter considers good matches for it
+type labelledProject struct {
+	Name     string           `json:"name"`
+	Project  matching.Project `json:"project"`
+	Relevant []string         `json:"relevant"`
+}
+
+type projectMetrics struct {
+	Project string `json:"project"`
+	matching.RankingMetrics
+}
+
+type scorerResults struct {
+	Scorer   string                  `json:"scorer"`
+	K        int                     `json:"k"`
+	Mean     matching.RankingMetrics `json:"mean"`
+	Projects []projectMetrics        `json:"projects"`
+}
+
+//Run evaluates the rankings of every scorer for the labelled projects and returns the exit code of the process.
+//It returns 2 for usage errors, 3 when a labelled project is invalid, and 1 when projects can't be matched
+func Run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("eval", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	labelsPath := flags.String("labels", "", "labelled project JSON file, or a directory of them")
+	participantsPath := flags.String("participants", "", "csv file with the participants")
+	scorersPath := flags.String("scorers", "", "experiment YAML file whose variants are the scorers to evaluate, the default scorer is evaluated when it's empty")
+	jobTitleTaxonomy := flags.String("job-title-taxonomy", "", "YAML file with the job title roles and synonyms, the built-in taxonomy is used when it's empty")
+	relevance := flags.Bool("relevance", false, "rank participants by the BM25 relevance of their job title and industries for the project terms")
+	k := flags.Int("k", 10, "amount of participants of every ranking that are evaluated")
+	format := flags.String("format", "table", "output format: table or json")
+	diff := flags.String("diff", "", "two scorers separated by a comma, prints the metrics of the second one relative to the first one")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *labelsPath == "" || *participantsPath == "" {
+		fmt.Fprintln(stderr, "-labels and -participants are required")
+		flags.Usage()
+		return exitUsage
+	}
+	if *k <= 0 {
+		fmt.Fprintln(stderr, "-k must be greater than 0")
+		return exitUsage
+	}
+	if !contains(formats, *format) {
+		fmt.Fprintf(stderr, "unknown format %q, use one of %s\n", *format, strings.Join(formats, ", "))
+		return exitUsage
+	}
+	baseline, candidate := "", ""
+	if *diff != "" {
+		names := strings.Split(*diff, ",")
+		if len(names) != 2 || names[0] == "" || names[1] == "" {
+			fmt.Fprintln(stderr, "-diff must be two scorers separated by a comma")
+			return exitUsage
+		}
+		baseline, candidate = names[0], names[1]
+	}
+
+	labels, err := readLabels(*labelsPath)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitInvalidProject
+	}
+
+	logger := logging.New(stderr, logging.Options{Level: logging.WarnLevel, Format: logging.TextFormat})
+	repository, err := storage.NewCsvParticipantsRepositoryWithGeocoder(*participantsPath, storage.NewCsvCityGeocoder(), logger)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+	scoreOptions, err := storage.LoadScoreOptions(config.Config{JobTitleTaxonomy: *jobTitleTaxonomy, Relevance: *relevance}, repository)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	scorers, err := loadScorers(*scorersPath, scoreOptions)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	if *diff != "" {
+		for _, name := range []string{baseline, candidate} {
+			if findScorer(scorers, name) == nil {
+				fmt.Fprintf(stderr, "unknown scorer %q in -diff\n", name)
+				return exitUsage
+			}
+		}
+		scorers = []matching.Variant{*findScorer(scorers, baseline), *findScorer(scorers, candidate)}
+	}
+
+	results := []scorerResults{}
+	for _, scorer := range scorers {
+		result, err := evaluate(repository, scorer, labels, *k, logger)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
+		results = append(results, result)
+	}
+
+	if *diff != "" {
+		err = writeDiff(stdout, *format, results[0], results[1])
+	} else {
+		err = writeResults(stdout, *format, results)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+//loadScorers returns the variants of the experiment at path, or a single default scorer when there is none
+func loadScorers(path string, options []matching.ScoreOption) ([]matching.Variant, error) {
+	if path == "" {
+		return []matching.Variant{{Name: defaultScorer, Share: 1, Score: matching.NewScoreService(options...)}}, nil
+	}
+	experiment, err := storage.LoadExperiment(path, options...)
+	if err != nil {
+		return nil, err
+	}
+	return experiment.Variants(), nil
+}
+
+func findScorer(scorers []matching.Variant, name string) *matching.Variant {
+	for i := range scorers {
+		if scorers[i].Name == name {
+			return &scorers[i]
+		}
+	}
+	return nil
+}
+
+//evaluate ranks the participants of every labelled project with the scorer, and measures the rankings against the labels
+func evaluate(repository matching.ParticipantRepository, scorer matching.Variant, labels []labelledProject, k int, logger logging.Logger) (scorerResults, error) {
+	action := matching.NewMatchingParticipantsAction(repository, matching.NewDistanceService(), scorer.Score, matching.WithLogger(logger))
+	result := scorerResults{Scorer: scorer.Name, K: k, Projects: []projectMetrics{}}
+	metrics := []matching.RankingMetrics{}
+	for _, label := range labels {
+		participants, err := action.GetMatchingParticipantsForProject(context.Background(), label.Project)
+		if err != nil {
+			return result, fmt.Errorf("%s: %s: %s", scorer.Name, label.Name, err)
+		}
+		ranked := make([]string, len(participants))
+		for i, participant := range participants {
+			ranked[i] = participant.ID
+		}
+		projectResult := matching.EvaluateRanking(ranked, label.Relevant, k)
+		metrics = append(metrics, projectResult)
+		result.Projects = append(result.Projects, projectMetrics{Project: label.Name, RankingMetrics: projectResult})
+	}
+	result.Mean = matching.MeanRankingMetrics(metrics)
+	return result, nil
+}
+
+//readLabels reads and validates every labelled project before evaluating any of them
+func readLabels(path string) ([]labelledProject, error) {
+	paths, err := labelPaths(path)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := []labelledProject{}
+	problems := []string{}
+	for _, labelPath := range paths {
+		label, err := readLabel(labelPath)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", labelPath, err))
+			continue
+		}
+		labels = append(labels, label)
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(problems, "\n"))
+	}
+	return labels, nil
+}
+
+func labelPaths(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%s: there are no labelled project JSON files", path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func readLabel(path string) (labelledProject, error) {
+	label := labelledProject{}
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return label, err
+	}
+	if err := json.Unmarshal(body, &label); err != nil {
+		return label, err
+	}
+	if label.Name == "" {
+		label.Name = path
+	}
+	if len(label.Relevant) == 0 {
+		return label, errors.New("at least one relevant participant is required")
+	}
+	return label, label.Project.Validate()
+}
+
+func writeResults(w io.Writer, format string, results []scorerResults) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		for _, result := range results {
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "SCORER\tPRECISION@%d\tRECALL@%d\tNDCG@%d\tMRR\n", results[0].K, results[0].K, results[0].K)
+	for _, result := range results {
+		fmt.Fprintf(table, "%s\t%.3f\t%.3f\t%.3f\t%.3f\n", result.Scorer, result.Mean.Precision, result.Mean.Recall, result.Mean.NDCG, result.Mean.MRR)
+	}
+	return table.Flush()
+}
+
+type projectDiff struct {
+	Project   string                  `json:"project"`
+	Baseline  matching.RankingMetrics `json:"baseline"`
+	Candidate matching.RankingMetrics `json:"candidate"`
+	Delta     matching.RankingMetrics `json:"delta"`
+}
+
+type scorersDiff struct {
+	Baseline  string        `json:"baseline"`
+	Candidate string        `json:"candidate"`
+	K         int           `json:"k"`
+	Mean      projectDiff   `json:"mean"`
+	Projects  []projectDiff `json:"projects"`
+}
+
+//writeDiff prints the metrics of the candidate for every project and their mean, with the change from the baseline
+func writeDiff(w io.Writer, format string, baseline, candidate scorerResults) error {
+	diff := scorersDiff{
+		Baseline:  baseline.Scorer,
+		Candidate: candidate.Scorer,
+		K:         baseline.K,
+		Mean:      newProjectDiff("mean", baseline.Mean, candidate.Mean),
+		Projects:  []projectDiff{},
+	}
+	for i := range baseline.Projects {
+		diff.Projects = append(diff.Projects, newProjectDiff(baseline.Projects[i].Project, baseline.Projects[i].RankingMetrics, candidate.Projects[i].RankingMetrics))
+	}
+
+	if format == "json" {
+		return json.NewEncoder(w).Encode(diff)
+	}
+
+	fmt.Fprintf(w, "%s compared to %s\n", diff.Candidate, diff.Baseline)
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "PROJECT\tPRECISION@%d\tRECALL@%d\tNDCG@%d\tMRR\n", diff.K, diff.K, diff.K)
+	for _, project := range append(diff.Projects, diff.Mean) {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", project.Project,
+			change(project.Candidate.Precision, project.Delta.Precision),
+			change(project.Candidate.Recall, project.Delta.Recall),
+			change(project.Candidate.NDCG, project.Delta.NDCG),
+			change(project.Candidate.MRR, project.Delta.MRR))
+	}
+	return table.Flush()
+}
+
+func newProjectDiff(project string, baseline, candidate matching.RankingMetrics) projectDiff {
+	return projectDiff{Project: project, Baseline: baseline, Candidate: candidate, Delta: candidate.Sub(baseline)}
+}
+
+func change(value, delta float64) string {
+	return fmt.Sprintf("%.3f (%+.3f)", value, delta)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
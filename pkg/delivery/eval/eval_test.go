@@ -0,0 +1,76 @@
+package eval
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const participantsPath = "../../infrastructure/storage/respondents_data_test.csv"
+
+func TestRun(t *testing.T) {
+	t.Run("Given labelled projects and an experiment, When the evaluation runs with json format, Then must print the metrics of every scorer", func(t *testing.T) {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+		exitCode := Run([]string{"-labels", "testdata/labels", "-participants", participantsPath, "-scorers", "testdata/scorers.yaml", "-k", "2", "-format", "json"}, &stdout, &stderr)
+
+		assert.Equal(t, exitOK, exitCode, stderr.String())
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		assert.Equal(t, 2, len(lines))
+		control, industry := scorerResults{}, scorerResults{}
+		assert.Nil(t, json.Unmarshal([]byte(lines[0]), &control))
+		assert.Nil(t, json.Unmarshal([]byte(lines[1]), &industry))
+		assert.Equal(t, "control", control.Scorer)
+		assert.Equal(t, "industry", industry.Scorer)
+		assert.Equal(t, 2, len(control.Projects))
+		assert.Equal(t, "brooklyn-designers", control.Projects[0].Project)
+		assert.Equal(t, "kafka-engineers", control.Projects[1].Project)
+		assert.Equal(t, 1.0, control.Projects[1].Precision)
+		assert.Equal(t, 1.0, control.Projects[1].NDCG)
+		assert.True(t, industry.Mean.NDCG < control.Mean.NDCG)
+	})
+	t.Run("Given labelled projects without scorers, When the evaluation runs, Then must print a table with the default scorer", func(t *testing.T) {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+		exitCode := Run([]string{"-labels", "testdata/labels/new_york.json", "-participants", participantsPath, "-k", "2"}, &stdout, &stderr)
+
+		assert.Equal(t, exitOK, exitCode, stderr.String())
+		assert.True(t, strings.HasPrefix(stdout.String(), "SCORER"))
+		assert.Contains(t, stdout.String(), "PRECISION@2")
+		assert.Contains(t, stdout.String(), "default")
+	})
+	t.Run("Given two scorers to diff, When the evaluation runs, Then must print the candidate metrics with the change from the baseline", func(t *testing.T) {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+		exitCode := Run([]string{"-labels", "testdata/labels", "-participants", participantsPath, "-scorers", "testdata/scorers.yaml", "-k", "2", "-diff", "control,industry", "-format", "json"}, &stdout, &stderr)
+
+		assert.Equal(t, exitOK, exitCode, stderr.String())
+		diff := scorersDiff{}
+		assert.Nil(t, json.Unmarshal(stdout.Bytes(), &diff))
+		assert.Equal(t, "control", diff.Baseline)
+		assert.Equal(t, "industry", diff.Candidate)
+		assert.Equal(t, 2, len(diff.Projects))
+		assert.Equal(t, -0.5, diff.Projects[1].Delta.Precision)
+		assert.InDelta(t, diff.Mean.Candidate.NDCG-diff.Mean.Baseline.NDCG, diff.Mean.Delta.NDCG, 0.000001)
+	})
+	t.Run("Given a diff with an unknown scorer, When the evaluation runs, Then must exit with the usage code", func(t *testing.T) {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+		exitCode := Run([]string{"-labels", "testdata/labels", "-participants", participantsPath, "-scorers", "testdata/scorers.yaml", "-diff", "control,unknown"}, &stdout, &stderr)
+
+		assert.Equal(t, exitUsage, exitCode)
+		assert.Contains(t, stderr.String(), `unknown scorer "unknown"`)
+	})
+	t.Run("Given a labelled project without relevant participants, When the evaluation runs, Then must exit with the invalid project code", func(t *testing.T) {
+		stdout, stderr := bytes.Buffer{}, bytes.Buffer{}
+
+		exitCode := Run([]string{"-labels", "testdata/invalid", "-participants", participantsPath}, &stdout, &stderr)
+
+		assert.Equal(t, exitInvalidProject, exitCode)
+		assert.Contains(t, stderr.String(), "at least one relevant participant is required")
+		assert.Equal(t, "", stdout.String())
+	})
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+type csvResultsWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvResultsWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return w.writer.Write(resultsHeader)
+}
+
+func (w *csvResultsWriter) Write(participant matching.MatchingParticipant) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	return w.writer.Write(resultsRow(participant))
+}
+
+func (w *csvResultsWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+//NewCsvResultsWriter returns a ResultsWriter that writes CSV rows, starting with a header row
+func NewCsvResultsWriter(w io.Writer) ResultsWriter {
+	return &csvResultsWriter{
+		writer: csv.NewWriter(w),
+	}
+}
+
+type csvProjectsResultsWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvProjectsResultsWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return w.writer.Write(append([]string{"project"}, resultsHeader...))
+}
+
+func (w *csvProjectsResultsWriter) Write(project string, participant matching.MatchingParticipant) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	return w.writer.Write(append([]string{project}, resultsRow(participant)...))
+}
+
+func (w *csvProjectsResultsWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+//NewCsvProjectsResultsWriter returns a ProjectsResultsWriter that writes the results of every project to a single CSV
+//with one header, so it can be loaded as a whole
+func NewCsvProjectsResultsWriter(w io.Writer) ProjectsResultsWriter {
+	return &csvProjectsResultsWriter{
+		writer: csv.NewWriter(w),
+	}
+}
@@ -0,0 +1,73 @@
+package export
+
+import (
+	"strconv"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+//CsvContentType is the media type of results exported as CSV
+const CsvContentType = "text/csv"
+
+//XlsxContentType is the media type of results exported as an Excel workbook
+const XlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+var resultsHeader = []string{
+	"participant_id",
+	"name",
+	"city",
+	"location_id",
+	"distance",
+	"score",
+	"industry_score",
+	"job_title_score",
+	"seniority_score",
+	"gender_score",
+	"education_score",
+	"relevance_score",
+}
+
+//ResultsWriter writes MatchingParticipants one row at a time, so results don't need to be
+//encoded in memory before being sent
+type ResultsWriter interface {
+	Write(participant matching.MatchingParticipant) error
+	Close() error
+}
+
+//ProjectsResultsWriter writes the MatchingParticipants of several projects as a single table, with the project
+//of every row in a leading project column
+type ProjectsResultsWriter interface {
+	Write(project string, participant matching.MatchingParticipant) error
+	Close() error
+}
+
+func resultsRow(p matching.MatchingParticipant) []string {
+	return []string{
+		p.ID,
+		p.Name,
+		p.City,
+		p.LocationID,
+		formatFloat(p.Distance),
+		formatFloat(p.Score),
+		formatFloat(p.Breakdown.Industry),
+		formatFloat(p.Breakdown.JobTitle),
+		formatFloat(p.Breakdown.Seniority),
+		formatFloat(p.Breakdown.Gender),
+		formatFloat(p.Breakdown.Education),
+		formatFloat(p.Breakdown.Relevance),
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+//WriteAll writes every participant with the ResultsWriter and closes it
+func WriteAll(w ResultsWriter, participants []matching.MatchingParticipant) error {
+	for _, participant := range participants {
+		if err := w.Write(participant); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
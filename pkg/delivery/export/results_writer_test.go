@@ -0,0 +1,126 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultsWriter(t *testing.T) {
+	participants := []matching.MatchingParticipant{
+		matching.MatchingParticipant{
+			ID:         "7f3a",
+			Name:       "Jillian",
+			City:       "New York, NY, USA",
+			LocationID: "ChIJOwg_06VPwokRYv534QaPC8g",
+			Distance:   6.5,
+			Score:      2.5,
+			Breakdown: matching.ScoreBreakdown{
+				Industry:  1,
+				JobTitle:  1,
+				Seniority: 0.5,
+				Gender:    0.25,
+				Education: 0.125,
+				Relevance: 0.75,
+			},
+		},
+		matching.MatchingParticipant{
+			ID:   "9b2c",
+			Name: "Tom & \"Jerry\"",
+		},
+	}
+
+	t.Run("Given matching participants, When they are written as CSV, Then must write a header and a row for every participant", func(t *testing.T) {
+		output := bytes.Buffer{}
+
+		err := WriteAll(NewCsvResultsWriter(&output), participants)
+
+		assert.Nil(t, err)
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		assert.Equal(t, 3, len(lines))
+		assert.Equal(t, strings.Join(resultsHeader, ","), lines[0])
+		assert.Equal(t, `7f3a,Jillian,"New York, NY, USA",ChIJOwg_06VPwokRYv534QaPC8g,6.5,2.5,1,1,0.5,0.25,0.125,0.75`, lines[1])
+	})
+	t.Run("Given the participants of several projects, When they are written as CSV, Then must write one header and the project of every row", func(t *testing.T) {
+		output := bytes.Buffer{}
+		writer := NewCsvProjectsResultsWriter(&output)
+
+		assert.Nil(t, writer.Write("brooklyn.json", participants[0]))
+		assert.Nil(t, writer.Write("new_york.json", participants[1]))
+		assert.Nil(t, writer.Close())
+
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		assert.Equal(t, 3, len(lines))
+		assert.Equal(t, "project,"+strings.Join(resultsHeader, ","), lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "brooklyn.json,7f3a,"))
+		assert.True(t, strings.HasPrefix(lines[2], "new_york.json,9b2c,"))
+	})
+	t.Run("Given no matching participants, When they are written as CSV, Then must only write the header", func(t *testing.T) {
+		output := bytes.Buffer{}
+
+		err := WriteAll(NewCsvResultsWriter(&output), []matching.MatchingParticipant{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, strings.Join(resultsHeader, ",")+"\n", output.String())
+	})
+	t.Run("Given matching participants, When they are written as XLSX, Then must write a workbook with a row for every participant", func(t *testing.T) {
+		output := bytes.Buffer{}
+
+		err := WriteAll(NewXlsxResultsWriter(&output), participants)
+
+		assert.Nil(t, err)
+		archive, errZip := zip.NewReader(bytes.NewReader(output.Bytes()), int64(output.Len()))
+		assert.Nil(t, errZip)
+		sheet := ""
+		for _, file := range archive.File {
+			if file.Name == "xl/worksheets/sheet1.xml" {
+				content, _ := file.Open()
+				raw, _ := ioutil.ReadAll(content)
+				sheet = string(raw)
+			}
+		}
+		assert.Equal(t, 3, strings.Count(sheet, "<row "))
+		assert.Contains(t, sheet, "Tom &amp; &#34;Jerry&#34;")
+		assert.Contains(t, sheet, "<c><v>2.5</v></c>")
+	})
+	t.Run("Given matching participants, When they are written as XLSX, Then must write the gender, education and relevance scores as numbers", func(t *testing.T) {
+		output := bytes.Buffer{}
+
+		err := WriteAll(NewXlsxResultsWriter(&output), participants[:1])
+
+		assert.Nil(t, err)
+		sheet := xlsxSheet(t, output.Bytes())
+		assert.Contains(t, sheet, "<c><v>0.25</v></c>")
+		assert.Contains(t, sheet, "<c><v>0.125</v></c>")
+		assert.Contains(t, sheet, "<c><v>0.75</v></c>")
+	})
+	t.Run("Given matching participants, When they are written as a table, Then must write aligned columns with their rank", func(t *testing.T) {
+		output := bytes.Buffer{}
+
+		err := WriteAll(NewTableResultsWriter(&output), participants)
+
+		assert.Nil(t, err)
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		assert.Equal(t, 3, len(lines))
+		assert.True(t, strings.HasPrefix(lines[0], "RANK  ID    NAME"), lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "1     7f3a  Jillian"), lines[1])
+	})
+}
+
+func xlsxSheet(t *testing.T, workbook []byte) string {
+	archive, err := zip.NewReader(bytes.NewReader(workbook), int64(len(workbook)))
+	assert.Nil(t, err)
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			content, _ := file.Open()
+			raw, _ := ioutil.ReadAll(content)
+			return string(raw)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+var tableHeader = []string{"RANK", "ID", "NAME", "CITY", "DISTANCE", "SCORE"}
+
+type tableResultsWriter struct {
+	writer        *tabwriter.Writer
+	headerWritten bool
+	rank          int
+}
+
+func (w *tableResultsWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	_, err := fmt.Fprintln(w.writer, strings.Join(tableHeader, "\t"))
+	return err
+}
+
+func (w *tableResultsWriter) Write(participant matching.MatchingParticipant) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.rank++
+	_, err := fmt.Fprintf(w.writer, "%d\t%s\t%s\t%s\t%.2f\t%.2f\n", w.rank, participant.ID, participant.Name, participant.City, participant.Distance, participant.Score)
+	return err
+}
+
+func (w *tableResultsWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
+
+//NewTableResultsWriter returns a ResultsWriter that writes an aligned text table to be read in a terminal.
+//Rows are kept until Close to align the columns
+func NewTableResultsWriter(w io.Writer) ResultsWriter {
+	return &tableResultsWriter{
+		writer: tabwriter.NewWriter(w, 0, 0, 2, ' ', 0),
+	}
+}
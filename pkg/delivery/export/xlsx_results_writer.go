@@ -0,0 +1,140 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+//numericColumns are the resultsHeader columns written as numbers instead of text, the distance and every score
+var numericColumns = func() map[int]bool {
+	numeric := map[int]bool{}
+	for i, column := range resultsHeader {
+		if column == "distance" || column == "score" || strings.HasSuffix(column, "_score") {
+			numeric[i] = true
+		}
+	}
+	return numeric
+}()
+
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{
+		name: "[Content_Types].xml",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`,
+	},
+	{
+		name: "_rels/.rels",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`,
+	},
+	{
+		name: "xl/workbook.xml",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Matching" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`,
+	},
+	{
+		name: "xl/_rels/workbook.xml.rels",
+		content: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`,
+	},
+}
+
+//xlsxResultsWriter writes a single sheet workbook. The sheet is the last part of the zip
+//archive, so its rows are compressed and written as they arrive
+type xlsxResultsWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+	rows    int
+	err     error
+}
+
+func (w *xlsxResultsWriter) start() error {
+	if w.sheet != nil || w.err != nil {
+		return w.err
+	}
+	for _, part := range xlsxStaticParts {
+		partWriter, err := w.archive.Create(part.name)
+		if err != nil {
+			w.err = err
+			return err
+		}
+		if _, err := io.WriteString(partWriter, part.content); err != nil {
+			w.err = err
+			return err
+		}
+	}
+	sheetWriter, err := w.archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		w.err = err
+		return err
+	}
+	w.sheet = bufio.NewWriter(sheetWriter)
+	w.sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	return w.writeRow(resultsHeader, map[int]bool{})
+}
+
+func (w *xlsxResultsWriter) writeRow(values []string, numeric map[int]bool) error {
+	w.rows++
+	w.sheet.WriteString(`<row r="` + strconv.Itoa(w.rows) + `">`)
+	for i, value := range values {
+		if numeric[i] {
+			w.sheet.WriteString(`<c><v>` + value + `</v></c>`)
+			continue
+		}
+		w.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		xml.EscapeText(w.sheet, []byte(value))
+		w.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := w.sheet.WriteString(`</row>`)
+	if err != nil {
+		w.err = err
+	}
+	return err
+}
+
+func (w *xlsxResultsWriter) Write(participant matching.MatchingParticipant) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	return w.writeRow(resultsRow(participant), numericColumns)
+}
+
+func (w *xlsxResultsWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	w.sheet.WriteString(`</sheetData></worksheet>`)
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.archive.Close()
+}
+
+//NewXlsxResultsWriter returns a ResultsWriter that writes an Excel workbook with a single sheet
+func NewXlsxResultsWriter(w io.Writer) ResultsWriter {
+	return &xlsxResultsWriter{
+		archive: zip.NewWriter(w),
+	}
+}
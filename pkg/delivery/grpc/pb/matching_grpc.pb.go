@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// MatchingServiceClient is the client API for MatchingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MatchingServiceClient interface {
+	// MatchParticipants returns a page of participants sorted by matching score.
+	MatchParticipants(ctx context.Context, in *MatchParticipantsRequest, opts ...grpc.CallOption) (*MatchParticipantsResponse, error)
+	// StreamMatchingParticipants emits every matching participant as soon as it's scored,
+	// so results are not sorted.
+	StreamMatchingParticipants(ctx context.Context, in *StreamMatchingParticipantsRequest, opts ...grpc.CallOption) (MatchingService_StreamMatchingParticipantsClient, error)
+}
+
+type matchingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMatchingServiceClient(cc grpc.ClientConnInterface) MatchingServiceClient {
+	return &matchingServiceClient{cc}
+}
+
+func (c *matchingServiceClient) MatchParticipants(ctx context.Context, in *MatchParticipantsRequest, opts ...grpc.CallOption) (*MatchParticipantsResponse, error) {
+	out := new(MatchParticipantsResponse)
+	err := c.cc.Invoke(ctx, "/matching.v1.MatchingService/MatchParticipants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingServiceClient) StreamMatchingParticipants(ctx context.Context, in *StreamMatchingParticipantsRequest, opts ...grpc.CallOption) (MatchingService_StreamMatchingParticipantsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_MatchingService_serviceDesc.Streams[0], "/matching.v1.MatchingService/StreamMatchingParticipants", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &matchingServiceStreamMatchingParticipantsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MatchingService_StreamMatchingParticipantsClient interface {
+	Recv() (*MatchingParticipant, error)
+	grpc.ClientStream
+}
+
+type matchingServiceStreamMatchingParticipantsClient struct {
+	grpc.ClientStream
+}
+
+func (x *matchingServiceStreamMatchingParticipantsClient) Recv() (*MatchingParticipant, error) {
+	m := new(MatchingParticipant)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MatchingServiceServer is the server API for MatchingService service.
+// All implementations must embed UnimplementedMatchingServiceServer
+// for forward compatibility
+type MatchingServiceServer interface {
+	// MatchParticipants returns a page of participants sorted by matching score.
+	MatchParticipants(context.Context, *MatchParticipantsRequest) (*MatchParticipantsResponse, error)
+	// StreamMatchingParticipants emits every matching participant as soon as it's scored,
+	// so results are not sorted.
+	StreamMatchingParticipants(*StreamMatchingParticipantsRequest, MatchingService_StreamMatchingParticipantsServer) error
+	mustEmbedUnimplementedMatchingServiceServer()
+}
+
+// UnimplementedMatchingServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMatchingServiceServer struct {
+}
+
+func (UnimplementedMatchingServiceServer) MatchParticipants(context.Context, *MatchParticipantsRequest) (*MatchParticipantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatchParticipants not implemented")
+}
+func (UnimplementedMatchingServiceServer) StreamMatchingParticipants(*StreamMatchingParticipantsRequest, MatchingService_StreamMatchingParticipantsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMatchingParticipants not implemented")
+}
+func (UnimplementedMatchingServiceServer) mustEmbedUnimplementedMatchingServiceServer() {}
+
+// UnsafeMatchingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MatchingServiceServer will
+// result in compilation errors.
+type UnsafeMatchingServiceServer interface {
+	mustEmbedUnimplementedMatchingServiceServer()
+}
+
+func RegisterMatchingServiceServer(s grpc.ServiceRegistrar, srv MatchingServiceServer) {
+	s.RegisterService(&_MatchingService_serviceDesc, srv)
+}
+
+func _MatchingService_MatchParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchParticipantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).MatchParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/matching.v1.MatchingService/MatchParticipants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).MatchParticipants(ctx, req.(*MatchParticipantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingService_StreamMatchingParticipants_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMatchingParticipantsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchingServiceServer).StreamMatchingParticipants(m, &matchingServiceStreamMatchingParticipantsServer{stream})
+}
+
+type MatchingService_StreamMatchingParticipantsServer interface {
+	Send(*MatchingParticipant) error
+	grpc.ServerStream
+}
+
+type matchingServiceStreamMatchingParticipantsServer struct {
+	grpc.ServerStream
+}
+
+func (x *matchingServiceStreamMatchingParticipantsServer) Send(m *MatchingParticipant) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _MatchingService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "matching.v1.MatchingService",
+	HandlerType: (*MatchingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "MatchParticipants",
+			Handler:    _MatchingService_MatchParticipants_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMatchingParticipants",
+			Handler:       _MatchingService_StreamMatchingParticipants_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "matching.proto",
+}
@@ -0,0 +1,293 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/config"
+	"github.com/carlos-rodrigo/matching-app/pkg/delivery/grpc/pb"
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/metrics"
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/storage"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type matchingServer struct {
+	pb.UnimplementedMatchingServiceServer
+	Action matching.Action
+	logger logging.Logger
+}
+
+func (s *matchingServer) MatchParticipants(ctx context.Context, request *pb.MatchParticipantsRequest) (*pb.MatchParticipantsResponse, error) {
+	logger := s.logger.WithContext(ctx)
+	project, err := toProject(request.GetProject())
+	if err == nil {
+		err = project.Validate()
+	}
+	if err != nil {
+		logger.Warn("Invalid project", logging.Err(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	page, err := s.Action.GetMatchingParticipantsPageForProject(ctx, project, pageRequest(request))
+	if err != nil {
+		logger.Error("Can't get matching participants", logging.Err(err))
+		return nil, matchingStatus(err)
+	}
+
+	participants := make([]*pb.MatchingParticipant, 0, len(page.Participants))
+	for _, participant := range page.Participants {
+		participants = append(participants, fromMatchingParticipant(participant))
+	}
+
+	logger.Info("Results page served",
+		logging.F("count", len(participants)),
+		logging.F("total", page.Total),
+		logging.F("excluded", page.Excluded.Total()))
+	return &pb.MatchParticipantsResponse{
+		Participants: participants,
+		NextCursor:   page.NextCursor,
+		Total:        int32(page.Total),
+		Excluded: &pb.Exclusions{
+			Industry:  int32(page.Excluded.Industry),
+			JobTitle:  int32(page.Excluded.JobTitle),
+			Keyword:   int32(page.Excluded.Keyword),
+			Seniority: int32(page.Excluded.Seniority),
+			Query:     int32(page.Excluded.Query),
+			Required:  int32(page.Excluded.Required),
+			MinScore:  int32(page.Excluded.MinScore),
+			Quota:     int32(page.Excluded.Quota),
+			Capacity:  int32(page.Excluded.Capacity),
+			Cooldown:  int32(page.Excluded.Cooldown),
+		},
+		UnmetQuotas: fromUnmetQuotas(page.UnmetQuotas),
+		Allocations: fromAllocations(page.Allocations),
+	}, nil
+}
+
+func (s *matchingServer) StreamMatchingParticipants(request *pb.StreamMatchingParticipantsRequest, stream pb.MatchingService_StreamMatchingParticipantsServer) error {
+	logger := s.logger.WithContext(stream.Context())
+	project, err := toProject(request.GetProject())
+	if err == nil {
+		err = project.Validate()
+	}
+	if err != nil {
+		logger.Warn("Invalid project", logging.Err(err))
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	sent := 0
+	var errSend error
+	err = s.Action.StreamMatchingParticipantsForProject(stream.Context(), project, func(participant matching.MatchingParticipant) error {
+		sent++
+		errSend = stream.Send(fromMatchingParticipant(participant))
+		return errSend
+	})
+	if err != nil && err == errSend {
+		logger.Warn("Stream interrupted", logging.F("sent", sent), logging.Err(err))
+		return err
+	}
+	if err != nil {
+		logger.Error("Can't get matching participants", logging.F("sent", sent), logging.Err(err))
+		return matchingStatus(err)
+	}
+
+	logger.Info("Results streamed", logging.F("count", sent))
+	return nil
+}
+
+//pageRequest returns the page of the request. Like the HTTP server, requests without limit nor cursor get every result
+func pageRequest(request *pb.MatchParticipantsRequest) matching.PageRequest {
+	return matching.PageRequest{
+		Limit:  int(request.GetLimit()),
+		Cursor: request.GetCursor(),
+		All:    request.GetLimit() == 0 && request.GetCursor() == "",
+	}
+}
+
+//matchingStatus returns the status of an error of the matching action. Cursors that can't be decoded are
+//invalid arguments, the rest mean participants can't be matched now
+func matchingStatus(err error) error {
+	if err == matching.ErrInvalidCursor {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+func toProject(project *pb.Project) (matching.Project, error) {
+	cities := []matching.City{}
+	for _, city := range project.GetCities() {
+		location := city.GetLocation()
+		cities = append(cities, matching.City{
+			CityLocation: matching.CityLocation{
+				ID:               location.GetId(),
+				City:             location.GetCity(),
+				State:            location.GetState(),
+				Country:          location.GetCountry(),
+				FormattedAddress: location.GetFormattedAddress(),
+				Location: matching.Location{
+					Latitude:  location.GetLocation().GetLatitude(),
+					Longitude: location.GetLocation().GetLongitude(),
+				},
+			},
+			Capacity: int(city.GetCapacity()),
+		})
+	}
+
+	seniority, err := toSeniorityRange(project.GetSeniority())
+	if err != nil {
+		return matching.Project{}, err
+	}
+
+	return matching.Project{
+		ID:                    project.GetId(),
+		Name:                  project.GetName(),
+		Cities:                cities,
+		Genders:               project.GetGenders(),
+		ProfessionalIndustry:  project.GetProfessionalIndustry(),
+		ProfessionalJobTitles: project.GetProfessionalJobTitles(),
+		JobTitleThreshold:     project.GetJobTitleThreshold(),
+		Seniority:             seniority,
+		ExcludedIndustries:    project.GetExcludedIndustries(),
+		ExcludedJobTitles:     project.GetExcludedJobTitles(),
+		ExclusionKeywords:     project.GetExclusionKeywords(),
+		Education:             project.GetEducation(),
+		Required:              toCriteria(project.GetRequired()),
+		MinScore:              project.GetMinScore(),
+		Query:                 project.GetQuery(),
+		Quotas:                toQuotas(project.GetQuotas()),
+		SelectionSize:         int(project.GetSelectionSize()),
+		Fatigue:               toFatigueRule(project.GetFatigue()),
+	}, nil
+}
+
+func toFatigueRule(fatigue *pb.FatigueRule) *matching.FatigueRule {
+	if fatigue == nil {
+		return nil
+	}
+	return &matching.FatigueRule{
+		CooldownDays: int(fatigue.GetCooldownDays()),
+		Penalty:      fatigue.GetPenalty(),
+		WindowDays:   int(fatigue.GetWindowDays()),
+	}
+}
+
+func toQuotas(quotas []*pb.Quota) []matching.Quota {
+	result := []matching.Quota{}
+	for _, quota := range quotas {
+		result = append(result, matching.Quota{
+			Field: matching.QuotaField(quota.GetField()),
+			Value: quota.GetValue(),
+			Min:   int(quota.GetMin()),
+			Max:   int(quota.GetMax()),
+		})
+	}
+	return result
+}
+
+func fromAllocations(allocations []matching.CityAllocation) []*pb.CityAllocation {
+	result := make([]*pb.CityAllocation, 0, len(allocations))
+	for _, allocation := range allocations {
+		result = append(result, &pb.CityAllocation{
+			City:     allocation.City,
+			Capacity: int32(allocation.Capacity),
+			Assigned: int32(allocation.Assigned),
+		})
+	}
+	return result
+}
+
+func fromUnmetQuotas(unmet []matching.UnmetQuota) []*pb.UnmetQuota {
+	result := make([]*pb.UnmetQuota, 0, len(unmet))
+	for _, quota := range unmet {
+		result = append(result, &pb.UnmetQuota{
+			Quota: &pb.Quota{
+				Field: string(quota.Quota.Field),
+				Value: quota.Quota.Value,
+				Min:   int32(quota.Quota.Min),
+				Max:   int32(quota.Quota.Max),
+			},
+			Selected: int32(quota.Selected),
+		})
+	}
+	return result
+}
+
+func toCriteria(names []string) []matching.Criterion {
+	criteria := []matching.Criterion{}
+	for _, name := range names {
+		criteria = append(criteria, matching.Criterion(name))
+	}
+	return criteria
+}
+
+func toSeniorityRange(seniority *pb.SeniorityRange) (*matching.SeniorityRange, error) {
+	if seniority == nil {
+		return nil, nil
+	}
+	min, err := matching.ParseSeniority(seniority.GetMin())
+	if err != nil {
+		return nil, err
+	}
+	max, err := matching.ParseSeniority(seniority.GetMax())
+	if err != nil {
+		return nil, err
+	}
+	return &matching.SeniorityRange{Min: min, Max: max, Strict: seniority.GetStrict()}, nil
+}
+
+func fromMatchingParticipant(participant matching.MatchingParticipant) *pb.MatchingParticipant {
+	return &pb.MatchingParticipant{
+		Id:       participant.ID,
+		Name:     participant.Name,
+		Distance: participant.Distance,
+		Score:    participant.Score,
+		Breakdown: &pb.ScoreBreakdown{
+			Industry:  participant.Breakdown.Industry,
+			JobTitle:  participant.Breakdown.JobTitle,
+			Seniority: participant.Breakdown.Seniority,
+			Gender:    participant.Breakdown.Gender,
+			Education: participant.Breakdown.Education,
+			Relevance: participant.Breakdown.Relevance,
+		},
+		LocationId: participant.LocationID,
+		City:       participant.City,
+		Seniority:  participant.Seniority.String(),
+		Variant:    participant.Variant,
+		Fatigue:    participant.Fatigue,
+	}
+}
+
+//NewServer returns a gRPC Server with the MatchingService registered.
+//Every call gets an ID, taken from the x-request-id metadata when present, that is added to its log entries
+func NewServer(action matching.Action, logger logging.Logger) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(correlateUnary),
+		grpc.StreamInterceptor(correlateStream),
+	)
+	pb.RegisterMatchingServiceServer(server, &matchingServer{
+		Action: action,
+		logger: logger,
+	})
+	return server
+}
+
+//GetServer returns a new gRPC Server configurated with the given Config, ranking projects like the HTTP server,
+//and the http.Handler that exposes its Prometheus metrics
+func GetServer(cfg config.Config, logger logging.Logger) (*grpc.Server, http.Handler, error) {
+	geocoder, err := storage.NewGeocoder(cfg.Geocoder, cfg.MapsAPIKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	collector := metrics.NewPrometheusCollector()
+	repo := storage.NewAsyncCsvParticipantsRepository(cfg.DataSource, metrics.InstrumentGeocoder(geocoder, collector), logger)
+	collector.RegisterRepositorySize(repo.Size)
+	collector.RegisterKnownCities(repo.KnownAddress)
+	m, err := storage.LoadMatching(cfg, repo, collector, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return NewServer(m.Action, logger), collector.Handler(), nil
+}
@@ -0,0 +1,202 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/delivery/grpc/pb"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeAction struct {
+	participants []matching.MatchingParticipant
+	err          error
+	project      matching.Project
+	requestID    string
+	excluded     matching.Exclusions
+	page         matching.PageRequest
+}
+
+func (a *fakeAction) GetMatchingParticipantsForProject(ctx context.Context, p matching.Project) ([]matching.MatchingParticipant, error) {
+	a.project = p
+	a.requestID = logging.RequestID(ctx)
+	return a.participants, a.err
+}
+
+func (a *fakeAction) GetMatchingParticipantsPageForProject(ctx context.Context, p matching.Project, page matching.PageRequest) (matching.ParticipantsPage, error) {
+	a.project = p
+	a.page = page
+	a.requestID = logging.RequestID(ctx)
+	return matching.ParticipantsPage{Participants: a.participants, Total: len(a.participants), NextCursor: "next", Excluded: a.excluded}, a.err
+}
+
+func (a *fakeAction) StreamMatchingParticipantsForProject(ctx context.Context, p matching.Project, emit func(matching.MatchingParticipant) error) error {
+	a.project = p
+	a.requestID = logging.RequestID(ctx)
+	for _, participant := range a.participants {
+		if err := emit(participant); err != nil {
+			return err
+		}
+	}
+	return a.err
+}
+
+func dialServer(t *testing.T, action matching.Action) pb.MatchingServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewServer(action, logging.New(ioutil.Discard, logging.Options{}))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	assert.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewMatchingServiceClient(conn)
+}
+
+func TestMatchingServer(t *testing.T) {
+	project := &pb.Project{
+		Cities: []*pb.City{
+			&pb.City{
+				Location: &pb.CityLocation{
+					Id:               "ChIJOwg_06VPwokRYv534QaPC8g",
+					FormattedAddress: "New York, NY, USA",
+					Location:         &pb.Location{Latitude: 40.7127753, Longitude: -74.0059728},
+				},
+			},
+		},
+		ProfessionalJobTitles: []string{"Software Engineer"},
+	}
+	participants := []matching.MatchingParticipant{
+		matching.MatchingParticipant{ID: "a", Name: "Jillian", Score: 1.5, Breakdown: matching.ScoreBreakdown{JobTitle: 1, Seniority: 0.5}},
+		matching.MatchingParticipant{ID: "b", Name: "Jefferson", Score: 1, Breakdown: matching.ScoreBreakdown{JobTitle: 1}},
+	}
+
+	t.Run("Given a project, When MatchParticipants is called, Then must return the page of matching participants", func(t *testing.T) {
+		action := &fakeAction{participants: participants}
+		client := dialServer(t, action)
+
+		response, err := client.MatchParticipants(context.Background(), &pb.MatchParticipantsRequest{Project: project, Limit: 2})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "New York, NY, USA", action.project.Cities[0].CityLocation.FormattedAddress)
+		assert.Equal(t, 2, len(response.GetParticipants()))
+		assert.Equal(t, "Jillian", response.GetParticipants()[0].GetName())
+		assert.Equal(t, 0.5, response.GetParticipants()[0].GetBreakdown().GetSeniority())
+		assert.Equal(t, "next", response.GetNextCursor())
+		assert.Equal(t, matching.PageRequest{Limit: 2}, action.page)
+	})
+	t.Run("Given a project without limit nor cursor, When MatchParticipants is called, Then must ask the action for every participant", func(t *testing.T) {
+		action := &fakeAction{participants: participants}
+		client := dialServer(t, action)
+
+		_, err := client.MatchParticipants(context.Background(), &pb.MatchParticipantsRequest{Project: project})
+
+		assert.Nil(t, err)
+		assert.True(t, action.page.All)
+	})
+	t.Run("Given a cursor without limit, When MatchParticipants is called, Then must ask the action for the next page", func(t *testing.T) {
+		action := &fakeAction{participants: participants}
+		client := dialServer(t, action)
+
+		_, err := client.MatchParticipants(context.Background(), &pb.MatchParticipantsRequest{Project: project, Cursor: "next"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, matching.PageRequest{Cursor: "next"}, action.page)
+	})
+	t.Run("Given a project with exclusions, When MatchParticipants is called, Then must pass them to the action and return the excluded counts", func(t *testing.T) {
+		action := &fakeAction{participants: participants, excluded: matching.Exclusions{Industry: 2, Keyword: 1}}
+		client := dialServer(t, action)
+		withExclusions := &pb.Project{
+			Cities:             project.Cities,
+			ExcludedIndustries: []string{"Market Research"},
+			ExcludedJobTitles:  []string{"Recruiter"},
+			ExclusionKeywords:  []string{"staffing"},
+		}
+
+		response, err := client.MatchParticipants(context.Background(), &pb.MatchParticipantsRequest{Project: withExclusions})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Market Research"}, action.project.ExcludedIndustries)
+		assert.Equal(t, []string{"Recruiter"}, action.project.ExcludedJobTitles)
+		assert.Equal(t, []string{"staffing"}, action.project.ExclusionKeywords)
+		assert.Equal(t, int32(2), response.GetExcluded().GetIndustry())
+		assert.Equal(t, int32(1), response.GetExcluded().GetKeyword())
+	})
+	t.Run("Given a call with a request ID, When MatchParticipants is called, Then the ID must reach the action and the response header", func(t *testing.T) {
+		action := &fakeAction{participants: participants}
+		client := dialServer(t, action)
+		ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDMetadata, "request-1")
+		header := metadata.MD{}
+
+		_, err := client.MatchParticipants(ctx, &pb.MatchParticipantsRequest{Project: project}, grpc.Header(&header))
+
+		assert.Nil(t, err)
+		assert.Equal(t, "request-1", action.requestID)
+		assert.Equal(t, []string{"request-1"}, header.Get(RequestIDMetadata))
+	})
+	t.Run("Given a project, When participants can't be retrieved, Then must return an unavailable status", func(t *testing.T) {
+		client := dialServer(t, &fakeAction{err: matching.ErrCantGetParticipantsNow})
+
+		_, err := client.MatchParticipants(context.Background(), &pb.MatchParticipantsRequest{Project: project})
+
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+	t.Run("Given a project without cities, When MatchParticipants is called, Then must return an invalid argument status", func(t *testing.T) {
+		client := dialServer(t, &fakeAction{participants: participants})
+
+		_, err := client.MatchParticipants(context.Background(), &pb.MatchParticipantsRequest{Project: &pb.Project{}})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("Given a project with an unknown seniority, When MatchParticipants is called, Then must return an invalid argument status", func(t *testing.T) {
+		client := dialServer(t, &fakeAction{participants: participants})
+		withSeniority := &pb.Project{Cities: project.Cities, Seniority: &pb.SeniorityRange{Min: "guru"}}
+
+		_, err := client.MatchParticipants(context.Background(), &pb.MatchParticipantsRequest{Project: withSeniority})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "unknown seniority")
+	})
+	t.Run("Given a project, When StreamMatchingParticipants is called, Then must receive every matching participant", func(t *testing.T) {
+		action := &fakeAction{participants: participants}
+		client := dialServer(t, action)
+
+		stream, err := client.StreamMatchingParticipants(context.Background(), &pb.StreamMatchingParticipantsRequest{Project: project})
+		assert.Nil(t, err)
+		received := []string{}
+		for {
+			participant, errRecv := stream.Recv()
+			if errRecv == io.EOF {
+				break
+			}
+			assert.Nil(t, errRecv)
+			received = append(received, participant.GetId())
+		}
+
+		assert.Equal(t, []string{"a", "b"}, received)
+		assert.Len(t, action.requestID, 16)
+	})
+	t.Run("Given participants that are still loading, When StreamMatchingParticipants is called, Then must return an unavailable status", func(t *testing.T) {
+		client := dialServer(t, &fakeAction{err: matching.ErrParticipantsLoading})
+
+		stream, err := client.StreamMatchingParticipants(context.Background(), &pb.StreamMatchingParticipantsRequest{Project: project})
+		assert.Nil(t, err)
+		_, errRecv := stream.Recv()
+
+		assert.Equal(t, codes.Unavailable, status.Code(errRecv))
+		assert.Equal(t, matching.ErrParticipantsLoading.Error(), status.Convert(errRecv).Message())
+	})
+}
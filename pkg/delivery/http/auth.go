@@ -0,0 +1,60 @@
+package http
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/auth"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/julienschmidt/httprouter"
+)
+
+//APIKeyHeader is the header that carries the API key, as an alternative to an Authorization Bearer header
+const APIKeyHeader = "X-API-Key"
+
+//authorize returns a handler that only serves requests with an API key of the required scope,
+//and rejects them with 429 once the key runs out of its rate limit
+func authorize(keys auth.KeyStore, limiter *auth.RateLimiter, required auth.Scope, logger logging.Logger, handle httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		logger := logger.WithContext(r.Context())
+		key := readAPIKey(r)
+		if key == "" {
+			w.Header().Add("WWW-Authenticate", `Bearer realm="matching"`)
+			writeResponseWithoutData(w, http.StatusUnauthorized, "API key required")
+			return
+		}
+		apiKey, err := keys.Find(key)
+		if err != nil {
+			logger.Warn("Request with an unknown API key", logging.F("path", r.URL.Path))
+			w.Header().Add("WWW-Authenticate", `Bearer realm="matching", error="invalid_token"`)
+			writeResponseWithoutData(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		if !apiKey.Scope.Allows(required) {
+			logger.Warn("API key without scope", logging.F("client", apiKey.Name), logging.F("scope", string(required)))
+			writeResponseWithoutData(w, http.StatusForbidden, "API key can't access "+string(required)+" routes")
+			return
+		}
+		allowed, retryAfter := limiter.Allow(apiKey)
+		if !allowed {
+			logger.Warn("API key rate limited", logging.F("client", apiKey.Name))
+			w.Header().Add("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			writeResponseWithoutData(w, http.StatusTooManyRequests, "Rate limit exceeded")
+			return
+		}
+		handle(w, r, params)
+	}
+}
+
+func readAPIKey(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+	authorization := r.Header.Get("Authorization")
+	if len(authorization) > len("Bearer ") && strings.EqualFold(authorization[:len("Bearer ")], "Bearer ") {
+		return strings.TrimSpace(authorization[len("Bearer "):])
+	}
+	return ""
+}
@@ -0,0 +1,87 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/auth"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeKeyStore map[string]auth.APIKey
+
+func (s fakeKeyStore) Find(key string) (auth.APIKey, error) {
+	apiKey, ok := s[key]
+	if !ok {
+		return auth.APIKey{}, auth.ErrUnknownKey
+	}
+	return apiKey, nil
+}
+
+func TestAuthorize(t *testing.T) {
+	keys := fakeKeyStore{
+		"read-key":  auth.APIKey{Name: "analyst", Scope: auth.ScopeRead, RateLimit: 1, Burst: 1},
+		"write-key": auth.APIKey{Name: "recruiter", Scope: auth.ScopeWrite, RateLimit: 1, Burst: 1},
+		"admin-key": auth.APIKey{Name: "operator", Scope: auth.ScopeAdmin, RateLimit: 1, Burst: 1},
+	}
+	logger := logging.New(ioutil.Discard, logging.Options{})
+	ok := func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		w.WriteHeader(http.StatusOK)
+	}
+	serveWith := func(limiter *auth.RateLimiter, scope auth.Scope, header string, key string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/matching/", nil)
+		if key != "" {
+			request.Header.Set(header, key)
+		}
+		authorize(keys, limiter, scope, logger, ok)(recorder, request, nil)
+		return recorder
+	}
+
+	t.Run("Given a request without API key, When it's authorized, Then must return unauthorized", func(t *testing.T) {
+		recorder := serveWith(auth.NewRateLimiter(), auth.ScopeRead, APIKeyHeader, "")
+
+		assert.Equal(t, 401, recorder.Code)
+		assert.NotEmpty(t, recorder.Header().Get("WWW-Authenticate"))
+	})
+	t.Run("Given a request with an unknown API key, When it's authorized, Then must return unauthorized", func(t *testing.T) {
+		recorder := serveWith(auth.NewRateLimiter(), auth.ScopeRead, APIKeyHeader, "guessed-key")
+
+		assert.Equal(t, 401, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "Unknown API key")
+	})
+	t.Run("Given a read key, When an admin route is requested, Then must return forbidden", func(t *testing.T) {
+		recorder := serveWith(auth.NewRateLimiter(), auth.ScopeAdmin, APIKeyHeader, "read-key")
+
+		assert.Equal(t, 403, recorder.Code)
+	})
+	t.Run("Given a read key, When a write route is requested, Then must return forbidden", func(t *testing.T) {
+		recorder := serveWith(auth.NewRateLimiter(), auth.ScopeWrite, APIKeyHeader, "read-key")
+
+		assert.Equal(t, 403, recorder.Code)
+	})
+	t.Run("Given a write key, When a write route is requested, Then must serve it", func(t *testing.T) {
+		recorder := serveWith(auth.NewRateLimiter(), auth.ScopeWrite, APIKeyHeader, "write-key")
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+	t.Run("Given an admin key in a Bearer header, When a read route is requested, Then must serve it", func(t *testing.T) {
+		recorder := serveWith(auth.NewRateLimiter(), auth.ScopeRead, "Authorization", "Bearer admin-key")
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+	t.Run("Given a key out of its rate limit, When a route is requested, Then must return too many requests with Retry-After", func(t *testing.T) {
+		limiter := auth.NewRateLimiter()
+		first := serveWith(limiter, auth.ScopeRead, APIKeyHeader, "read-key")
+
+		second := serveWith(limiter, auth.ScopeRead, APIKeyHeader, "read-key")
+
+		assert.Equal(t, 200, first.Code)
+		assert.Equal(t, 429, second.Code)
+		assert.Equal(t, "1", second.Header().Get("Retry-After"))
+	})
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/delivery/export"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+var exportFileNames = map[string]string{
+	export.CsvContentType:  "matching.csv",
+	export.XlsxContentType: "matching.xlsx",
+}
+
+//negotiateExportContentType returns the first export format accepted by the request,
+//or an empty string when the results must be returned as JSON
+func negotiateExportContentType(r *http.Request) string {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		if mediaType == "application/json" {
+			return ""
+		}
+		if _, ok := exportFileNames[mediaType]; ok {
+			return mediaType
+		}
+	}
+	return ""
+}
+
+func newResultsWriter(w http.ResponseWriter, contentType string) export.ResultsWriter {
+	if contentType == export.XlsxContentType {
+		return export.NewXlsxResultsWriter(w)
+	}
+	return export.NewCsvResultsWriter(w)
+}
+
+//export writes every matching participant of the project to the response, ignoring pagination. Rows are sorted
+//like the JSON results, so the ranking is built before writing them, but they are encoded while they are written
+func (h *matchingHandler) export(w http.ResponseWriter, r *http.Request, project matching.Project, contentType string) {
+	logger := h.logger.WithContext(r.Context())
+	participants, errMatching := h.Action.GetMatchingParticipantsForProject(experimentContext(r), project)
+	if errMatching == matching.ErrParticipantsLoading {
+		writeParticipantsLoading(w)
+		return
+	}
+	if errMatching != nil {
+		logger.Error("Can't get matching participants", logging.Err(errMatching))
+		writeResponseWithoutData(w, http.StatusInternalServerError, errMatching.Error())
+		return
+	}
+
+	w.Header().Add("Content-Type", contentType)
+	w.Header().Add("Content-Disposition", `attachment; filename="`+exportFileNames[contentType]+`"`)
+	w.WriteHeader(http.StatusOK)
+	if err := export.WriteAll(newResultsWriter(w, contentType), participants); err != nil {
+		logger.Error("Can't export results", logging.F("contentType", contentType), logging.Err(err))
+		return
+	}
+
+	logger.Info("Results exported", logging.F("contentType", contentType), logging.F("count", len(participants)))
+}
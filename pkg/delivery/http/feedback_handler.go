@@ -0,0 +1,64 @@
+package http
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/julienschmidt/httprouter"
+)
+
+type feedbackHandler struct {
+	recorder matching.FeedbackRecorder
+	logger   logging.Logger
+}
+
+func (h *feedbackHandler) Perform(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	request := matching.FeedbackRequest{}
+	logger := h.logger.WithContext(r.Context())
+
+	body, errReadBody := ioutil.ReadAll(r.Body)
+	if errReadBody != nil {
+		logger.Warn("Can't read body", logging.Err(errReadBody))
+		writeResponseWithoutData(w, http.StatusBadRequest, "Can't read body from request")
+		return
+	}
+	if errUnmarshal := json.Unmarshal(body, &request); errUnmarshal != nil {
+		logger.Warn("Incorrect body", logging.Err(errUnmarshal))
+		writeResponseWithoutData(w, http.StatusUnprocessableEntity, "Incorrect Body")
+		return
+	}
+
+	feedback, err := h.recorder.RecordFeedback(experimentContext(r), request)
+	if _, ok := err.(matching.ValidationError); ok {
+		logger.Warn("Invalid feedback", logging.Err(err))
+		writeResponseWithoutData(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err == matching.ErrParticipantNotFound {
+		writeResponseWithoutData(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err == matching.ErrParticipantsLoading {
+		writeParticipantsLoading(w)
+		return
+	}
+	if err != nil {
+		logger.Error("Can't record feedback", logging.Err(err))
+		writeResponseWithoutData(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	logger.Info("Feedback recorded", logging.F("accepted", feedback.Accepted), logging.F("variant", feedback.Variant))
+	writeResponse(w, http.StatusCreated, "Feedback recorded", feedback)
+}
+
+//NewFeedbackHandler returns a Handler that records the decisions of recruiters about participants
+func NewFeedbackHandler(recorder matching.FeedbackRecorder, logger logging.Logger) Handler {
+	return &feedbackHandler{
+		recorder: recorder,
+		logger:   logger,
+	}
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/stretchr/testify/assert"
+)
+
+type participantFinder map[string]matching.Participant
+
+func (f participantFinder) GetByID(id string) (matching.Participant, error) {
+	participant, ok := f[id]
+	if !ok {
+		return matching.Participant{}, matching.ErrParticipantNotFound
+	}
+	return participant, nil
+}
+
+type feedbackRepository struct {
+	feedback []matching.Feedback
+}
+
+func (r *feedbackRepository) Save(feedback matching.Feedback) error {
+	r.feedback = append(r.feedback, feedback)
+	return nil
+}
+
+func TestFeedbackHandler(t *testing.T) {
+	participants := participantFinder{"1": {ID: "1", JobTitle: "Java Developer"}}
+	repository := &feedbackRepository{}
+	recorder := matching.NewFeedbackRecorder(participants, matching.NewScoreService(), repository)
+	handler := NewFeedbackHandler(recorder, logging.New(ioutil.Discard, logging.Options{}))
+	perform := func(body string) (int, ResponseBody) {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/feedback", strings.NewReader(body)).WithContext(context.Background())
+		handler.Perform(recorder, request, nil)
+		response := ResponseBody{}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		return recorder.Code, response
+	}
+
+	t.Run("Given a participant decision, When it's posted, Then must be recorded with the participant features", func(t *testing.T) {
+		code, _ := perform(`{"projectId":"p1","project":{"professionalJobTitles":["Java Developer"]},"participantId":"1","accepted":true}`)
+
+		assert.Equal(t, 201, code)
+		assert.Equal(t, 1, len(repository.feedback))
+		assert.Equal(t, 1.0, repository.feedback[0].Features.JobTitle)
+	})
+	t.Run("Given an unknown participant, When the decision is posted, Then must respond not found", func(t *testing.T) {
+		code, _ := perform(`{"participantId":"2","accepted":false}`)
+
+		assert.Equal(t, 404, code)
+	})
+	t.Run("Given a decision without accepted, When it's posted, Then must respond what's missing", func(t *testing.T) {
+		code, response := perform(`{"participantId":"1"}`)
+
+		assert.Equal(t, 422, code)
+		assert.Equal(t, "Invalid feedback: accepted is required", response.Message)
+	})
+}
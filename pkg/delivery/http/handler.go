@@ -4,7 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/carlos-rodrigo/matching-app/pkg/auth"
+	"github.com/carlos-rodrigo/matching-app/pkg/config"
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/metrics"
 	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/storage"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
 	"github.com/carlos-rodrigo/matching-app/pkg/matching"
 	"github.com/julienschmidt/httprouter"
 )
@@ -18,13 +22,19 @@ func writeResponseWithoutData(w http.ResponseWriter, statusCode int, message str
 	writeResponse(w, statusCode, message, nil)
 }
 func writeResponse(w http.ResponseWriter, statusCode int, message string, data interface{}) {
-	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	body := ResponseBody{
+	writeResponseWithNext(w, statusCode, message, data, "")
+}
+func writeResponseWithNext(w http.ResponseWriter, statusCode int, message string, data interface{}, next string) {
+	writeResponseBody(w, ResponseBody{
 		Code:    statusCode,
 		Message: message,
 		Data:    data,
-	}
+		Next:    next,
+	})
+}
+func writeResponseBody(w http.ResponseWriter, body ResponseBody) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(body.Code)
 	errEncode := json.NewEncoder(w).Encode(body)
 	if errEncode != nil {
 		panic(errEncode)
@@ -36,21 +46,87 @@ type ResponseBody struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
+	Next    string      `json:"next,omitempty"`
+	//Excluded counts the participants filtered out by the project exclusions
+	Excluded *matching.Exclusions `json:"excluded,omitempty"`
+	//QueryError describes where the project query can't be parsed
+	QueryError *matching.QueryError `json:"queryError,omitempty"`
+	//UnmetQuotas are the quotas of the project whose min couldn't be reached
+	UnmetQuotas []matching.UnmetQuota `json:"unmetQuotas,omitempty"`
+	//Allocations are the participants assigned to every project city in allocation mode
+	Allocations []matching.CityAllocation `json:"allocations,omitempty"`
 }
 
-func matchingParticipants() Handler {
-	repo := storage.NewCsvParticipantsRepository("./respondents_data_test.csv")
-	distance := matching.NewDistanceService()
-	score := matching.NewScoreService()
-	action := matching.NewMatchingParticipantsAction(repo, distance, score)
-	handler := NewMatchingParticipantsHandler(action)
+//GetRouter returns a new Router configurated with the given Config.
+//Participants are loaded in background, and /readyz reports when they are available.
+//Every request gets an ID, taken from the X-Request-ID header when present, that is added to its log entries.
+//When the Config has an API keys file, /matching/ requires a read key, POST /feedback and POST /participations a write key
+//and /metrics an admin key.
+//POST /feedback records recruiter decisions when the Config has a feedback file.
+//POST /participations records the participation history that fatigue rules use when the Config has a participation file.
+//When the Config has an experiment, projects are split between its variants by the X-Experiment-Key header or their ID
+func GetRouter(cfg config.Config, logger logging.Logger) (*httprouter.Router, error) {
+	geocoder, err := storage.NewGeocoder(cfg.Geocoder, cfg.MapsAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	protect, err := newAuthorization(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	collector := metrics.NewPrometheusCollector()
+	repo := storage.NewAsyncCsvParticipantsRepository(cfg.DataSource, metrics.InstrumentGeocoder(geocoder, collector), logger)
+	collector.RegisterRepositorySize(repo.Size)
+	collector.RegisterKnownCities(repo.KnownAddress)
+	m, err := storage.LoadMatching(cfg, repo, collector, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	router := httprouter.New()
+	handle := func(route string, handler httprouter.Handle) {
+		router.GET(route, correlate(logger, route, instrument(collector, route, handler)))
+	}
+	handle("/healthz", healthz)
+	handle("/readyz", readyz(repo))
+	handle("/matching/", protect(auth.ScopeRead, NewMatchingParticipantsHandler(m.Action, logger).Perform))
+	handle("/openapi.json", openAPI)
+	handle("/docs", docs)
+	handle("/docs.js", docsAssets)
+	handle("/metrics", protect(auth.ScopeAdmin, serve(collector.Handler())))
+	if cfg.FeedbackFile != "" {
+		recorder := matching.NewFeedbackRecorder(repo, m.Score, storage.NewJSONLinesFeedbackRepository(cfg.FeedbackFile),
+			matching.WithFeedbackExperiment(m.Experiment),
+			matching.WithFeedbackMetrics(collector))
+		router.POST("/feedback", correlate(logger, "/feedback", instrument(collector, "/feedback", protect(auth.ScopeWrite, NewFeedbackHandler(recorder, logger).Perform))))
+	}
+	if m.History != nil {
+		router.POST("/participations", correlate(logger, "/participations", instrument(collector, "/participations", protect(auth.ScopeWrite, NewParticipationHandler(m.History, logger).Perform))))
+	}
+	return router, nil
+}
 
-	return handler
+//newAuthorization returns a function that protects a route with the API keys of the Config,
+//or leaves it open when the Config has no keys file
+func newAuthorization(cfg config.Config, logger logging.Logger) (func(auth.Scope, httprouter.Handle) httprouter.Handle, error) {
+	if cfg.AuthKeysFile == "" {
+		logger.Warn("No API keys file configured, every route is open")
+		return func(_ auth.Scope, handle httprouter.Handle) httprouter.Handle {
+			return handle
+		}, nil
+	}
+	keys, err := auth.NewFileKeyStore(cfg.AuthKeysFile)
+	if err != nil {
+		return nil, err
+	}
+	limiter := auth.NewRateLimiter()
+	return func(scope auth.Scope, handle httprouter.Handle) httprouter.Handle {
+		return authorize(keys, limiter, scope, logger, handle)
+	}, nil
 }
 
-//GetRouter returns a new configurated Router
-func GetRouter() *httprouter.Router {
-	router := httprouter.New()
-	router.GET("/matching/", matchingParticipants().Perform)
-	return router
+func serve(handler http.Handler) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		handler.ServeHTTP(w, r)
+	}
 }
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/storage"
+	"github.com/julienschmidt/httprouter"
+)
+
+//LoadStatusChecker represents a dependency that is loaded in background
+type LoadStatusChecker interface {
+	Status() (storage.LoadStatus, error)
+}
+
+func healthz(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeResponseWithoutData(w, http.StatusOK, "ok")
+}
+
+//readyz returns a handler that reports the service as ready once the checker is loaded
+func readyz(checker LoadStatusChecker) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		status, err := checker.Status()
+		switch status {
+		case storage.StatusReady:
+			writeResponseWithoutData(w, http.StatusOK, string(status))
+		case storage.StatusFailed:
+			writeResponseWithoutData(w, http.StatusServiceUnavailable, string(status)+": "+err.Error())
+		default:
+			writeResponseWithoutData(w, http.StatusServiceUnavailable, string(status))
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeLoadStatusChecker struct {
+	status storage.LoadStatus
+	err    error
+}
+
+func (c fakeLoadStatusChecker) Status() (storage.LoadStatus, error) {
+	return c.status, c.err
+}
+
+func TestReadyz(t *testing.T) {
+	t.Run("Given participants are loading, When readiness is checked, Then must return service unavailable", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		readyz(fakeLoadStatusChecker{status: storage.StatusLoading})(recorder, httptest.NewRequest("GET", "/readyz", nil), nil)
+
+		assert.Equal(t, 503, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "loading")
+	})
+	t.Run("Given participants failed to load, When readiness is checked, Then must return service unavailable with the error", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		readyz(fakeLoadStatusChecker{status: storage.StatusFailed, err: errors.New("Couldn't open the csv file")})(recorder, httptest.NewRequest("GET", "/readyz", nil), nil)
+
+		assert.Equal(t, 503, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "failed: Couldn't open the csv file")
+	})
+	t.Run("Given participants are loaded, When readiness is checked, Then must return ok", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		readyz(fakeLoadStatusChecker{status: storage.StatusReady})(recorder, httptest.NewRequest("GET", "/readyz", nil), nil)
+
+		assert.Equal(t, 200, recorder.Code)
+	})
+}
@@ -1,54 +1,151 @@
 package http
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
-	"log"
 	"net/http"
+	"strconv"
 
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
 	"github.com/carlos-rodrigo/matching-app/pkg/matching"
 	"github.com/julienschmidt/httprouter"
 )
 
 type matchingHandler struct {
 	Action matching.Action
+	logger logging.Logger
 }
 
 func (h *matchingHandler) Perform(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	project := matching.Project{}
+	logger := h.logger.WithContext(r.Context())
+
+	page, errPage := readPageRequest(r)
+	if errPage != nil {
+		logger.Warn("Invalid page request", logging.Err(errPage))
+		writeResponseWithoutData(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
 
 	body, errReadBody := ioutil.ReadAll(r.Body)
 	if errReadBody != nil {
-		log.Println(errReadBody)
+		logger.Warn("Can't read body", logging.Err(errReadBody))
 		writeResponseWithoutData(w, http.StatusBadRequest, "Can't read body from request")
 		return
 	}
 	errCloseReadBody := r.Body.Close()
 	if errCloseReadBody != nil {
-		log.Println(errCloseReadBody)
+		logger.Warn("Can't close body", logging.Err(errCloseReadBody))
 		writeResponseWithoutData(w, http.StatusBadRequest, "Can't read body from request")
 		return
 	}
 	errUnmarshalProject := json.Unmarshal(body, &project)
 	if errUnmarshalProject != nil {
-		log.Println(errUnmarshalProject)
+		logger.Warn("Incorrect body", logging.Err(errUnmarshalProject))
 		writeResponseWithoutData(w, http.StatusUnprocessableEntity, "Incorrect Body")
 		return
 	}
-	participants, errMatching := h.Action.GetMatchingParticipantsForProject(project)
+	errValidation := project.Validate()
+	if errValidation != nil {
+		logger.Warn("Invalid project", logging.Err(errValidation))
+		body := ResponseBody{Code: http.StatusUnprocessableEntity, Message: errValidation.Error()}
+		if validation, ok := errValidation.(matching.ValidationError); ok {
+			body.QueryError = validation.Query
+		}
+		writeResponseBody(w, body)
+		return
+	}
+	if contentType := negotiateExportContentType(r); contentType != "" {
+		h.export(w, r, project, contentType)
+		return
+	}
+	participants, errMatching := h.Action.GetMatchingParticipantsPageForProject(experimentContext(r), project, page)
+	if errMatching == matching.ErrInvalidCursor {
+		logger.Warn("Invalid cursor", logging.Err(errMatching))
+		writeResponseWithoutData(w, http.StatusBadRequest, errMatching.Error())
+		return
+	}
+	if errMatching == matching.ErrParticipantsLoading {
+		writeParticipantsLoading(w)
+		return
+	}
 	if errMatching != nil {
-		log.Println(errMatching)
+		logger.Error("Can't get matching participants", logging.Err(errMatching))
 		writeResponseWithoutData(w, http.StatusInternalServerError, errMatching.Error())
 		return
 	}
 
-	log.Println("Results count: ", len(participants))
-	writeResponse(w, http.StatusOK, "Successful Login!", participants)
+	logger.Info("Results page served",
+		logging.F("count", len(participants.Participants)),
+		logging.F("total", participants.Total),
+		logging.F("excluded", participants.Excluded.Total()))
+	if participants.Variant != "" {
+		w.Header().Add(experimentVariantHeader, participants.Variant)
+	}
+	writeResponseBody(w, ResponseBody{
+		Code:        http.StatusOK,
+		Message:     "Successful Login!",
+		Data:        participants.Participants,
+		Next:        nextPageLink(r, participants),
+		Excluded:    &participants.Excluded,
+		UnmetQuotas: participants.UnmetQuotas,
+		Allocations: participants.Allocations,
+	})
+}
+
+//experimentKeyHeader assigns a request to an experiment variant instead of the project ID
+const experimentKeyHeader = "X-Experiment-Key"
+
+//experimentVariantHeader tells the experiment variant that scored the participants of a response
+const experimentVariantHeader = "X-Experiment-Variant"
+
+//experimentContext returns the context of the request with the key of its experimentKeyHeader, when it has one
+func experimentContext(r *http.Request) context.Context {
+	if key := r.Header.Get(experimentKeyHeader); key != "" {
+		return matching.WithExperimentKey(r.Context(), key)
+	}
+	return r.Context()
+}
+
+func writeParticipantsLoading(w http.ResponseWriter) {
+	w.Header().Add("Retry-After", "10")
+	writeResponseWithoutData(w, http.StatusServiceUnavailable, matching.ErrParticipantsLoading.Error())
+}
+
+//readPageRequest returns the page requested in the query. Requests without limit nor cursor get every result,
+//as they did before results were paginated
+func readPageRequest(r *http.Request) (matching.PageRequest, error) {
+	query := r.URL.Query()
+	page := matching.PageRequest{
+		Cursor: query.Get("cursor"),
+		All:    query.Get("limit") == "" && query.Get("cursor") == "",
+	}
+	if limit := query.Get("limit"); limit != "" {
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil || parsedLimit < 0 {
+			return matching.PageRequest{}, errors.New("Invalid limit " + limit)
+		}
+		page.Limit = parsedLimit
+	}
+	return page, nil
+}
+
+//nextPageLink returns the link to the page following the given one, or an empty string for the last page
+func nextPageLink(r *http.Request, page matching.ParticipantsPage) string {
+	if page.NextCursor == "" {
+		return ""
+	}
+	query := r.URL.Query()
+	query.Set("cursor", page.NextCursor)
+	return r.URL.Path + "?" + query.Encode()
 }
 
 //NewMatchingParticipantsHandler returns an initialized LoginUserHandler
-func NewMatchingParticipantsHandler(action matching.Action) Handler {
+func NewMatchingParticipantsHandler(action matching.Action, logger logging.Logger) Handler {
 	return &matchingHandler{
 		Action: action,
+		logger: logger,
 	}
 }
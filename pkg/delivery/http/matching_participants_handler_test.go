@@ -0,0 +1,126 @@
+package http
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchingParticipantsHandler(t *testing.T) {
+	handler := NewMatchingParticipantsHandler(nil, logging.New(ioutil.Discard, logging.Options{}))
+
+	t.Run("Given a project with a query that can't be parsed, When matching participants are requested, Then the response body must describe where the query is wrong", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		body := `{"cities":[{"location":{"formattedAddress":"New York, NY, USA"}}],"query":"(industry:Banking OR titel:Java)"}`
+		request := httptest.NewRequest("GET", "/matching/", strings.NewReader(body))
+
+		handler.Perform(recorder, request, nil)
+
+		response := ResponseBody{}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, 422, recorder.Code)
+		assert.Contains(t, response.Message, "query: unknown field \"titel\"")
+		assert.Equal(t, &matching.QueryError{
+			Message:  `unknown field "titel", must be one of city, education, gender, industry, seniority, title`,
+			Position: 22,
+		}, response.QueryError)
+	})
+	t.Run("Given an action with an experiment, When matching participants are requested with an experiment key, Then the response must tell the variant that scored them", func(t *testing.T) {
+		experiment, _ := matching.NewExperiment("titles",
+			matching.Variant{Name: "control", Share: 1, Score: matching.NewScoreService()},
+			matching.Variant{Name: "titles", Share: 1, Score: matching.NewScoreService(matching.WithScoreWeights(matching.ScoreWeights{JobTitle: 1}))})
+		repository := participantsByAddress{"New York, NY, USA": {{ID: "1", JobTitle: "Java Developer"}}}
+		action := matching.NewMatchingParticipantsAction(repository, matching.NewDistanceService(), matching.NewScoreService(), matching.WithExperiment(experiment))
+		handler := NewMatchingParticipantsHandler(action, logging.New(ioutil.Discard, logging.Options{}))
+		recorder := httptest.NewRecorder()
+		body := `{"id":"p1","cities":[{"location":{"formattedAddress":"New York, NY, USA"}}],"professionalJobTitles":["Java Developer"]}`
+		request := httptest.NewRequest("GET", "/matching/", strings.NewReader(body))
+		request.Header.Set("X-Experiment-Key", "client-7")
+
+		handler.Perform(recorder, request, nil)
+
+		variant := experiment.Assign("client-7").Name
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, variant, recorder.Header().Get("X-Experiment-Variant"))
+		assert.Contains(t, recorder.Body.String(), `"variant":"`+variant+`"`)
+	})
+	t.Run("Given a project with a quota that can't be met, When matching participants are requested, Then the response body must report the unmet quota", func(t *testing.T) {
+		repository := participantsByAddress{"New York, NY, USA": {{ID: "1", Gender: "male", JobTitle: "Java Developer"}}}
+		action := matching.NewMatchingParticipantsAction(repository, matching.NewDistanceService(), matching.NewScoreService())
+		handler := NewMatchingParticipantsHandler(action, logging.New(ioutil.Discard, logging.Options{}))
+		recorder := httptest.NewRecorder()
+		body := `{"cities":[{"location":{"formattedAddress":"New York, NY, USA"}}],"quotas":[{"field":"gender","value":"female","min":2}]}`
+		request := httptest.NewRequest("GET", "/matching/", strings.NewReader(body))
+
+		handler.Perform(recorder, request, nil)
+
+		response := ResponseBody{}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, []matching.UnmetQuota{{
+			Quota:    matching.Quota{Field: matching.QuotaGender, Value: "female", Min: 2},
+			Selected: 0,
+		}}, response.UnmetQuotas)
+	})
+	t.Run("Given a request accepting CSV, When matching participants are requested, Then must write the rows sorted by score", func(t *testing.T) {
+		repository := participantsByAddress{"New York, NY, USA": {{ID: "1", Name: "Jefferson", JobTitle: "Sales Manager"}, {ID: "2", Name: "Jillian", JobTitle: "Java Developer"}}}
+		action := matching.NewMatchingParticipantsAction(repository, matching.NewDistanceService(), matching.NewScoreService())
+		handler := NewMatchingParticipantsHandler(action, logging.New(ioutil.Discard, logging.Options{}))
+		recorder := httptest.NewRecorder()
+		body := `{"cities":[{"location":{"formattedAddress":"New York, NY, USA"}}],"professionalJobTitles":["Java Developer"]}`
+		request := httptest.NewRequest("GET", "/matching/", strings.NewReader(body))
+		request.Header.Set("Accept", "text/csv")
+
+		handler.Perform(recorder, request, nil)
+
+		lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, 3, len(lines))
+		assert.True(t, strings.HasPrefix(lines[1], "2,Jillian,"))
+		assert.True(t, strings.HasPrefix(lines[2], "1,Jefferson,"))
+	})
+	t.Run("Given a request accepting CSV, When matching participants are requested, Then must write every participant as a CSV row", func(t *testing.T) {
+		repository := participantsByAddress{"New York, NY, USA": {{ID: "1", Name: "Jefferson"}, {ID: "2", Name: "Jillian"}}}
+		action := matching.NewMatchingParticipantsAction(repository, matching.NewDistanceService(), matching.NewScoreService())
+		handler := NewMatchingParticipantsHandler(action, logging.New(ioutil.Discard, logging.Options{}))
+		recorder := httptest.NewRecorder()
+		body := `{"cities":[{"location":{"formattedAddress":"New York, NY, USA"}}]}`
+		request := httptest.NewRequest("GET", "/matching/", strings.NewReader(body))
+		request.Header.Set("Accept", "text/csv")
+
+		handler.Perform(recorder, request, nil)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "text/csv", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, 3, len(strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")))
+		assert.Contains(t, recorder.Body.String(), "Jefferson")
+		assert.Contains(t, recorder.Body.String(), "Jillian")
+	})
+}
+
+func TestReadPageRequest(t *testing.T) {
+	t.Run("Given a request without limit nor cursor, When the page is read, Then must request every result", func(t *testing.T) {
+		page, err := readPageRequest(httptest.NewRequest("GET", "/matching/", nil))
+
+		assert.Nil(t, err)
+		assert.Equal(t, matching.PageRequest{All: true}, page)
+	})
+	t.Run("Given a request with a cursor, When the page is read, Then must request a page of the default limit", func(t *testing.T) {
+		page, err := readPageRequest(httptest.NewRequest("GET", "/matching/?cursor=abc", nil))
+
+		assert.Nil(t, err)
+		assert.Equal(t, matching.PageRequest{Cursor: "abc"}, page)
+	})
+}
+
+type participantsByAddress map[string][]matching.Participant
+
+func (p participantsByAddress) GetByFormattedAddress(address string) ([]matching.Participant, error) {
+	return p[address], nil
+}
@@ -0,0 +1,29 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/metrics"
+	"github.com/julienschmidt/httprouter"
+)
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+//instrument returns a handler that records the duration of every request to the route
+func instrument(collector *metrics.PrometheusCollector, route string, handle httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		handle(recorder, r, params)
+		collector.ObserveRequest(route, r.Method, recorder.status, time.Since(start))
+	}
+}
@@ -0,0 +1,705 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+//openAPISpecification describes the HTTP API. Schemas mirror matching.Project,
+//matching.MatchingParticipant and ResponseBody, and openapi_test.go keeps them in sync
+const openAPISpecification = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Matching App",
+    "description": "Matching projects with the best participant by industry, job title, and location.",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/matching/": {
+      "get": {
+        "summary": "Get the participants that match with a project",
+        "security": [{"apiKey": []}, {"bearer": []}],
+        "description": "Participants are located in less than 100km from one of the project cities and sorted by matching score in descendent order. The project is sent in the request body.",
+        "parameters": [
+          {
+            "name": "limit",
+            "in": "query",
+            "description": "Amount of participants in the page, up to 500. Every participant is returned when neither limit nor cursor are sent, and pages default to 50 participants when only the cursor is sent.",
+            "schema": {"type": "integer", "minimum": 0, "maximum": 500}
+          },
+          {
+            "name": "cursor",
+            "in": "query",
+            "description": "Cursor returned in the next link of the previous page.",
+            "schema": {"type": "string"}
+          },
+          {
+            "name": "Accept",
+            "in": "header",
+            "description": "Use text/csv or application/vnd.openxmlformats-officedocument.spreadsheetml.sheet to export every result instead of a JSON page, sorted like the JSON results.",
+            "schema": {"type": "string", "default": "application/json"}
+          },
+          {
+            "name": "X-Experiment-Key",
+            "in": "header",
+            "description": "Assigns the request to an experiment variant instead of the project id, only used when an experiment is configured.",
+            "schema": {"type": "string"}
+          },
+          {
+            "name": "X-Request-ID",
+            "in": "header",
+            "description": "ID added to the log entries of the request and returned in the response. Generated when missing.",
+            "schema": {"type": "string", "maxLength": 128}
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {"$ref": "#/components/schemas/Project"},
+              "examples": {
+                "project": {"$ref": "#/components/examples/Project"}
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "A page of matching participants",
+            "headers": {
+              "X-Experiment-Variant": {"description": "Experiment variant that scored the participants", "schema": {"type": "string"}}
+            },
+            "content": {
+              "application/json": {
+                "schema": {"$ref": "#/components/schemas/MatchingParticipantsResponse"},
+                "examples": {
+                  "participants": {"$ref": "#/components/examples/MatchingParticipantsResponse"}
+                }
+              },
+              "text/csv": {
+                "schema": {"type": "string"}
+              },
+              "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": {
+                "schema": {"type": "string", "format": "binary"}
+              }
+            }
+          },
+          "400": {
+            "description": "The body, limit or cursor can't be read",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          },
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "422": {
+            "description": "The body isn't a valid project, the message describes every problem found",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          },
+          "429": {"$ref": "#/components/responses/TooManyRequests"},
+          "500": {
+            "description": "Participants can't be retrieved now",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          },
+          "503": {
+            "description": "Participants are still loading",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          }
+        }
+      }
+    },
+    "/feedback": {
+      "post": {
+        "summary": "Record a recruiter accepting or rejecting a participant",
+        "description": "Stores the decision with the score of every criteria of the participant for the project, to train a ranking model with cmd/train. Only available when a feedback file is configured.",
+        "security": [{"apiKey": []}, {"bearer": []}],
+        "parameters": [
+          {
+            "name": "X-Experiment-Key",
+            "in": "header",
+            "description": "Key the project was matched with, to tag the feedback with its experiment variant.",
+            "schema": {"type": "string"}
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {"schema": {"$ref": "#/components/schemas/FeedbackRequest"}}
+          }
+        },
+        "responses": {
+          "201": {
+            "description": "The feedback recorded",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/FeedbackResponse"}}
+            }
+          },
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "404": {
+            "description": "There is no participant with the participantId",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          },
+          "422": {
+            "description": "The body isn't valid feedback, the message describes every problem found",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          },
+          "429": {"$ref": "#/components/responses/TooManyRequests"},
+          "503": {
+            "description": "Participants are still loading",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          }
+        }
+      }
+    },
+    "/participations": {
+      "post": {
+        "summary": "Record the participation of a participant in a project",
+        "description": "Stores the participation in the history the project fatigue rules are applied with. Only available when a participation file is configured.",
+        "security": [{"apiKey": []}, {"bearer": []}],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {"schema": {"$ref": "#/components/schemas/Participation"}}
+          }
+        },
+        "responses": {
+          "201": {
+            "description": "The participation recorded",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ParticipationResponse"}}
+            }
+          },
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "422": {
+            "description": "The body isn't a valid participation, the message describes every problem found",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          },
+          "429": {"$ref": "#/components/responses/TooManyRequests"},
+          "500": {
+            "description": "The participation can't be recorded",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          }
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "summary": "Get this OpenAPI specification",
+        "responses": {
+          "200": {"description": "The OpenAPI specification"}
+        }
+      }
+    },
+    "/docs": {
+      "get": {
+        "summary": "Browse the API documentation",
+        "responses": {
+          "200": {"description": "An HTML page rendering this specification"}
+        }
+      }
+    },
+    "/healthz": {
+      "get": {
+        "summary": "Check the process is up",
+        "responses": {
+          "200": {
+            "description": "The process is up",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          }
+        }
+      }
+    },
+    "/readyz": {
+      "get": {
+        "summary": "Check the participants are loaded",
+        "responses": {
+          "200": {
+            "description": "Participants are loaded and matching requests can be served",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          },
+          "503": {
+            "description": "Participants are loading, or failed to load",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+            }
+          }
+        }
+      }
+    },
+    "/metrics": {
+      "get": {
+        "summary": "Get the metrics in Prometheus text format",
+        "description": "Requires an admin API key.",
+        "security": [{"apiKey": []}, {"bearer": []}],
+        "responses": {
+          "200": {
+            "description": "Prometheus metrics",
+            "content": {
+              "text/plain": {"schema": {"type": "string"}}
+            }
+          },
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "429": {"$ref": "#/components/responses/TooManyRequests"}
+        }
+      }
+    }
+  },
+  "components": {
+    "securitySchemes": {
+      "apiKey": {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+      "bearer": {"type": "http", "scheme": "bearer"}
+    },
+    "responses": {
+      "Unauthorized": {
+        "description": "The API key is missing or unknown",
+        "content": {
+          "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+        }
+      },
+      "Forbidden": {
+        "description": "The API key scope can't access the route",
+        "content": {
+          "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+        }
+      },
+      "TooManyRequests": {
+        "description": "The API key exceeded its rate limit, retry after the seconds in the Retry-After header",
+        "headers": {
+          "Retry-After": {"schema": {"type": "integer"}}
+        },
+        "content": {
+          "application/json": {"schema": {"$ref": "#/components/schemas/ResponseBody"}}
+        }
+      }
+    },
+    "schemas": {
+      "Project": {
+        "type": "object",
+        "description": "Fields not listed here, like timezone or incentive, are accepted but ignored.",
+        "required": ["cities"],
+        "properties": {
+          "id": {"type": "string", "description": "ID of the project in the client, it assigns the project to an experiment variant"},
+          "name": {"type": "string", "example": "Looking for software engineers experienced with Kafka", "description": "Its words, with the job titles and industries, rank participants by relevance"},
+          "cities": {"type": "array", "items": {"$ref": "#/components/schemas/City"}},
+          "genders": {"type": "string", "description": "Comma separated genders looked for. Empty or N/A accepts any gender"},
+          "professionalIndustry": {"type": "array", "items": {"type": "string"}, "description": "Industries or industry groups, like Technology. Related industries of the same group get partial credit"},
+          "professionalJobTitles": {"type": "array", "items": {"type": "string"}, "description": "Titles are matched ignoring case, word order, punctuation and seniority words, and tolerating typos"},
+          "jobTitleThreshold": {"type": "number", "minimum": 0, "maximum": 1, "default": 0.8, "description": "Minimum similarity for a job title to score. A participant title scores its similarity with every expected title reaching it"},
+          "seniority": {"$ref": "#/components/schemas/SeniorityRange"},
+          "excludedIndustries": {"type": "array", "items": {"type": "string"}, "description": "Participants with any of these industries, or industries of these groups, are filtered out"},
+          "excludedJobTitles": {"type": "array", "items": {"type": "string"}, "description": "Participants whose job title matches any of these, like Recruiter, are filtered out"},
+          "exclusionKeywords": {"type": "array", "items": {"type": "string"}, "description": "Participants with any of these words in their job title or industries are filtered out"},
+          "education": {"type": "array", "items": {"type": "string"}, "description": "Education levels looked for, like Bachelor's Degree"},
+          "required": {"type": "array", "items": {"type": "string", "enum": ["industry", "jobTitle", "seniority", "gender", "education"]}, "description": "Criteria participants must meet to be in the results. Required criteria don't add to the score, the rest are preferred and do"},
+          "minScore": {"type": "number", "minimum": 0, "maximum": 1, "default": 0, "description": "Minimum score for a participant to be in the results"},
+          "query": {"type": "string", "example": "(industry:Banking OR industry:Insurance) AND title:\"Java\" AND NOT title:intern", "description": "Screening query participants must meet. Terms are field:value, with the fields industry, title, seniority, gender, education and city, joined with AND, OR, NOT and parenthesis"},
+          "quotas": {"type": "array", "items": {"$ref": "#/components/schemas/Quota"}, "description": "Min and max amount of participants selected by city, gender or industry. The best participants of the quotas below their min are selected first, then the best of the rest"},
+          "selectionSize": {"type": "integer", "minimum": 0, "default": 0, "description": "Amount of participants selected. When it's 0 every participant within the quota maximums is selected"},
+          "fatigue": {"$ref": "#/components/schemas/FatigueRule"}
+        }
+      },
+      "FatigueRule": {
+        "type": "object",
+        "description": "Protects participants from being invited to project after project, with the participations recorded in /participations. Declined participations don't count",
+        "properties": {
+          "cooldownDays": {"type": "integer", "minimum": 0, "default": 0, "description": "Participants with a participation in the last days, or a scheduled one, are filtered out"},
+          "penalty": {"type": "number", "minimum": 0, "maximum": 1, "default": 0, "description": "Subtracted from the score of a participant for every participation in the window"},
+          "windowDays": {"type": "integer", "minimum": 0, "default": 90, "description": "Days participations are penalized"}
+        }
+      },
+      "Quota": {
+        "type": "object",
+        "required": ["field", "value"],
+        "properties": {
+          "field": {"type": "string", "enum": ["city", "gender", "industry"]},
+          "value": {"type": "string", "example": "Miami", "description": "A project city by its id, city or formattedAddress, a gender, or an industry or industry group"},
+          "min": {"type": "integer", "minimum": 0, "default": 0},
+          "max": {"type": "integer", "minimum": 0, "default": 0, "description": "There is no maximum when it's 0"}
+        }
+      },
+      "UnmetQuota": {
+        "type": "object",
+        "description": "A quota whose min couldn't be reached with the matching participants",
+        "properties": {
+          "quota": {"$ref": "#/components/schemas/Quota"},
+          "selected": {"type": "integer", "description": "Participants selected for the quota"}
+        }
+      },
+      "Seniority": {
+        "type": "string",
+        "enum": ["intern", "junior", "mid", "senior", "staff", "lead", "manager", "director", "executive"],
+        "description": "Seniority level parsed from the job title. Titles without seniority words are mid level"
+      },
+      "SeniorityRange": {
+        "type": "object",
+        "description": "Seniority levels looked for. Participants out of the range score less for every level they are out of it",
+        "properties": {
+          "min": {"$ref": "#/components/schemas/Seniority"},
+          "max": {"$ref": "#/components/schemas/Seniority"},
+          "strict": {"type": "boolean", "default": false, "description": "Filters out participants out of the range instead of scoring them less"}
+        }
+      },
+      "City": {
+        "type": "object",
+        "properties": {
+          "location": {"$ref": "#/components/schemas/CityLocation"},
+          "capacity": {"type": "integer", "minimum": 0, "default": 0, "description": "Most participants assigned to the city when the server runs in allocation mode, unlimited when it's 0"}
+        }
+      },
+      "CityAllocation": {
+        "type": "object",
+        "description": "Participants assigned to a project city in allocation mode",
+        "properties": {
+          "city": {"type": "string"},
+          "capacity": {"type": "integer", "description": "Capacity of the city, 0 when it's unlimited"},
+          "assigned": {"type": "integer"}
+        }
+      },
+      "CityLocation": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "city": {"type": "string"},
+          "state": {"type": "string"},
+          "country": {"type": "string"},
+          "formattedAddress": {"type": "string", "description": "Participants are looked up by this address"},
+          "location": {"$ref": "#/components/schemas/Location"}
+        }
+      },
+      "Location": {
+        "type": "object",
+        "properties": {
+          "latitude": {"type": "number"},
+          "longitude": {"type": "number"}
+        }
+      },
+      "ScoreBreakdown": {
+        "type": "object",
+        "description": "Weighted contribution of every criteria to the score. Every criteria is scored from 0 to 1 before weighting",
+        "properties": {
+          "industry": {"type": "number"},
+          "jobTitle": {"type": "number"},
+          "seniority": {"type": "number"},
+          "gender": {"type": "number"},
+          "education": {"type": "number"},
+          "relevance": {"type": "number", "description": "BM25 relevance of the participant job title and industries for the project job titles, industries and name"}
+        }
+      },
+      "MatchingParticipant": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "name": {"type": "string"},
+          "distance": {"type": "number", "description": "Distance in km to the project city"},
+          "score": {"type": "number", "minimum": 0, "maximum": 1},
+          "breakdown": {"$ref": "#/components/schemas/ScoreBreakdown"},
+          "location_id": {"type": "string"},
+          "city": {"type": "string"},
+          "seniority": {"$ref": "#/components/schemas/Seniority"},
+          "variant": {"type": "string", "description": "Experiment variant that scored the participant, only when an experiment is configured"},
+          "fatigue": {"type": "number", "description": "Subtracted from the score by the fatigue rule of the project"}
+        }
+      },
+      "ResponseBody": {
+        "type": "object",
+        "properties": {
+          "code": {"type": "integer"},
+          "message": {"type": "string"},
+          "data": {},
+          "next": {"type": "string", "description": "Link to the next page of results"},
+          "excluded": {"$ref": "#/components/schemas/Exclusions"},
+          "queryError": {"$ref": "#/components/schemas/QueryError"},
+          "unmetQuotas": {"type": "array", "items": {"$ref": "#/components/schemas/UnmetQuota"}, "description": "Quotas of the project whose min couldn't be reached"},
+          "allocations": {"type": "array", "items": {"$ref": "#/components/schemas/CityAllocation"}, "description": "Participants assigned to every project city in allocation mode"}
+        }
+      },
+      "QueryError": {
+        "type": "object",
+        "description": "Where the project query can't be parsed",
+        "properties": {
+          "message": {"type": "string"},
+          "position": {"type": "integer", "description": "1-based offset of the query where the problem was found"}
+        }
+      },
+      "Exclusions": {
+        "type": "object",
+        "description": "Participants filtered out by the project exclusions, counted by the first reason found",
+        "properties": {
+          "industry": {"type": "integer"},
+          "jobTitle": {"type": "integer"},
+          "keyword": {"type": "integer"},
+          "seniority": {"type": "integer", "description": "Participants out of a strict seniority range"},
+          "query": {"type": "integer", "description": "Participants not meeting the query"},
+          "required": {"type": "integer", "description": "Participants not meeting the required criteria"},
+          "cooldown": {"type": "integer", "description": "Participants in the cooldown of the project fatigue rule"},
+          "minScore": {"type": "integer", "description": "Participants scoring below minScore"},
+          "quota": {"type": "integer", "description": "Participants left out of the selection by the quotas or selectionSize"},
+          "capacity": {"type": "integer", "description": "Participants that didn't fit in the city capacities in allocation mode"}
+        }
+      },
+      "FeedbackRequest": {
+        "type": "object",
+        "required": ["participantId", "accepted"],
+        "properties": {
+          "projectId": {"type": "string", "description": "ID of the project in the client, only stored with the feedback"},
+          "project": {"$ref": "#/components/schemas/Project"},
+          "participantId": {"type": "string"},
+          "accepted": {"type": "boolean"}
+        }
+      },
+      "Feedback": {
+        "type": "object",
+        "properties": {
+          "projectId": {"type": "string"},
+          "participantId": {"type": "string"},
+          "accepted": {"type": "boolean"},
+          "variant": {"type": "string", "description": "Experiment variant the project is assigned to"},
+          "features": {"$ref": "#/components/schemas/ScoreBreakdown", "description": "Score of every criteria from 0 to 1, before it's weighted"},
+          "recordedAt": {"type": "string", "format": "date-time"}
+        }
+      },
+      "FeedbackResponse": {
+        "allOf": [
+          {"$ref": "#/components/schemas/ResponseBody"},
+          {
+            "type": "object",
+            "properties": {
+              "data": {"$ref": "#/components/schemas/Feedback"}
+            }
+          }
+        ]
+      },
+      "Participation": {
+        "type": "object",
+        "required": ["participantId", "projectId", "date", "outcome"],
+        "properties": {
+          "participantId": {"type": "string"},
+          "projectId": {"type": "string"},
+          "date": {"type": "string", "format": "date-time", "description": "When the participant took part, or will take part, in the project"},
+          "outcome": {"type": "string", "enum": ["invited", "completed", "declined", "noShow"]}
+        }
+      },
+      "ParticipationResponse": {
+        "allOf": [
+          {"$ref": "#/components/schemas/ResponseBody"},
+          {
+            "type": "object",
+            "properties": {
+              "data": {"$ref": "#/components/schemas/Participation"}
+            }
+          }
+        ]
+      },
+      "MatchingParticipantsResponse": {
+        "allOf": [
+          {"$ref": "#/components/schemas/ResponseBody"},
+          {
+            "type": "object",
+            "properties": {
+              "data": {"type": "array", "items": {"$ref": "#/components/schemas/MatchingParticipant"}}
+            }
+          }
+        ]
+      }
+    },
+    "examples": {
+      "Project": {
+        "value": {
+          "cities": [
+            {
+              "location": {
+                "id": "ChIJOwg_06VPwokRYv534QaPC8g",
+                "city": "New York",
+                "state": "NY",
+                "country": "US",
+                "formattedAddress": "New York, NY, USA",
+                "location": {"latitude": 40.7127753, "longitude": -74.0059728}
+              }
+            }
+          ],
+          "genders": "N/A",
+          "professionalJobTitles": ["Developer", "Software Engineer"],
+          "professionalIndustry": ["Banking", "Computer Software"]
+        }
+      },
+      "MatchingParticipantsResponse": {
+        "value": {
+          "code": 200,
+          "message": "Successful Login!",
+          "data": [
+            {
+              "id": "3f1a9c0e5b7d2a64",
+              "name": "Jefferson",
+              "distance": 0,
+              "score": 2,
+              "breakdown": {"industry": 1, "jobTitle": 1, "seniority": 0},
+              "location_id": "ChIJOwg_06VPwokRYv534QaPC8g",
+              "city": "New York, NY, USA"
+            }
+          ],
+          "next": "/matching/?cursor=MjozZjFhOWMwZTViN2QyYTY0&limit=1"
+        }
+      }
+    }
+  }
+}
+`
+
+//docsPage renders the specification with docsScript. Both are served by the binary, so the documentation
+//works offline and doesn't run third-party scripts
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Matching App API</title>
+  <style>
+    body { font-family: sans-serif; margin: 2em auto; max-width: 60em; color: #222; }
+    section { border: 1px solid #ddd; border-radius: 4px; margin: 1em 0; padding: 0 1em; }
+    .method { font-weight: bold; text-transform: uppercase; margin-right: 0.5em; }
+    table { border-collapse: collapse; width: 100%; }
+    td, th { border-bottom: 1px solid #eee; padding: 0.3em; text-align: left; vertical-align: top; }
+    code { background: #f5f5f5; }
+  </style>
+</head>
+<body>
+  <h1 id="title">Matching App API</h1>
+  <p id="description"></p>
+  <p>The raw specification is served at <a href="/openapi.json">/openapi.json</a>.</p>
+  <h2>Routes</h2>
+  <div id="paths"></div>
+  <h2>Schemas</h2>
+  <div id="schemas"></div>
+  <script src="/docs.js"></script>
+</body>
+</html>
+`
+
+//docsScript lists the routes and schemas of the specification. Text is set with textContent, so the
+//specification can't inject markup
+const docsScript = `(function () {
+  function element(tag, text, className) {
+    var node = document.createElement(tag);
+    if (text) { node.textContent = text; }
+    if (className) { node.className = className; }
+    return node;
+  }
+  function schemaName(schema) {
+    if (!schema) { return ""; }
+    if (schema.$ref) { return schema.$ref.split("/").pop(); }
+    if (schema.type === "array") { return schemaName(schema.items) + "[]"; }
+    if (schema.allOf) { return schema.allOf.map(schemaName).join(" & "); }
+    return schema.type || "";
+  }
+  function table(headers, rows) {
+    var result = element("table");
+    var head = element("tr");
+    headers.forEach(function (header) { head.appendChild(element("th", header)); });
+    result.appendChild(head);
+    rows.forEach(function (row) {
+      var line = element("tr");
+      row.forEach(function (cell) { line.appendChild(element("td", cell)); });
+      result.appendChild(line);
+    });
+    return result;
+  }
+  function renderPaths(spec) {
+    var container = document.getElementById("paths");
+    Object.keys(spec.paths).forEach(function (path) {
+      Object.keys(spec.paths[path]).forEach(function (method) {
+        var operation = spec.paths[path][method];
+        var section = element("section");
+        var title = element("h3");
+        title.appendChild(element("span", method, "method"));
+        title.appendChild(element("code", path));
+        section.appendChild(title);
+        section.appendChild(element("p", operation.summary));
+        if (operation.description) { section.appendChild(element("p", operation.description)); }
+        if (operation.parameters) {
+          section.appendChild(table(["Parameter", "In", "Description"], operation.parameters.map(function (parameter) {
+            return [parameter.name, parameter["in"], parameter.description || ""];
+          })));
+        }
+        if (operation.requestBody) {
+          var content = operation.requestBody.content["application/json"];
+          section.appendChild(element("p", "Body: " + schemaName(content && content.schema)));
+        }
+        section.appendChild(table(["Status", "Description"], Object.keys(operation.responses).map(function (status) {
+          var response = operation.responses[status];
+          if (response.$ref) { response = spec.components.responses[response.$ref.split("/").pop()]; }
+          return [status, response.description];
+        })));
+        container.appendChild(section);
+      });
+    });
+  }
+  function renderSchemas(spec) {
+    var container = document.getElementById("schemas");
+    Object.keys(spec.components.schemas).forEach(function (name) {
+      var schema = spec.components.schemas[name];
+      var section = element("section");
+      section.appendChild(element("h3", name));
+      if (schema.description) { section.appendChild(element("p", schema.description)); }
+      if (schema.enum) { section.appendChild(element("p", "One of: " + schema.enum.join(", "))); }
+      if (schema.properties) {
+        section.appendChild(table(["Property", "Type", "Description"], Object.keys(schema.properties).map(function (property) {
+          var definition = schema.properties[property];
+          return [property, schemaName(definition), definition.description || ""];
+        })));
+      }
+      container.appendChild(section);
+    });
+  }
+  fetch("/openapi.json").then(function (response) { return response.json(); }).then(function (spec) {
+    document.getElementById("title").textContent = spec.info.title + " " + spec.info.version;
+    document.getElementById("description").textContent = spec.info.description;
+    renderPaths(spec);
+    renderSchemas(spec);
+  });
+})();
+`
+
+func openAPI(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(openAPISpecification))
+}
+
+//docsContentSecurityPolicy only allows the documentation to load the assets and the specification of the binary
+const docsContentSecurityPolicy = "default-src 'self'; style-src 'self' 'unsafe-inline'"
+
+func docs(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	w.Header().Add("Content-Type", "text/html; charset=utf-8")
+	w.Header().Add("Content-Security-Policy", docsContentSecurityPolicy)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(docsPage))
+}
+
+func docsAssets(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	w.Header().Add("Content-Type", "application/javascript; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(docsScript))
+}
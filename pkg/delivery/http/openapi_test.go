@@ -0,0 +1,149 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/stretchr/testify/assert"
+)
+
+func jsonFields(model reflect.Type) []string {
+	fields := []string{}
+	for i := 0; i < model.NumField(); i++ {
+		name := strings.Split(model.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func schemaProperties(schema map[string]interface{}) []string {
+	properties := []string{}
+	for name := range schema["properties"].(map[string]interface{}) {
+		properties = append(properties, name)
+	}
+	sort.Strings(properties)
+	return properties
+}
+
+func collectRefs(node interface{}, refs *[]string) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if key == "$ref" {
+				*refs = append(*refs, child.(string))
+				continue
+			}
+			collectRefs(child, refs)
+		}
+	case []interface{}:
+		for _, child := range value {
+			collectRefs(child, refs)
+		}
+	}
+}
+
+func decodeStrict(t *testing.T, example interface{}, target interface{}) error {
+	raw, err := json.Marshal(example)
+	assert.Nil(t, err)
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
+
+func TestOpenAPISpecification(t *testing.T) {
+	spec := map[string]interface{}{}
+	errSpec := json.Unmarshal([]byte(openAPISpecification), &spec)
+	components, _ := spec["components"].(map[string]interface{})
+	schemas, _ := components["schemas"].(map[string]interface{})
+	examples, _ := components["examples"].(map[string]interface{})
+
+	t.Run("Given the OpenAPI specification, When it's parsed, Then must be a valid JSON document with schemas and examples", func(t *testing.T) {
+		assert.Nil(t, errSpec)
+		assert.Equal(t, "3.0.3", spec["openapi"])
+		assert.NotEmpty(t, schemas)
+		assert.NotEmpty(t, examples)
+	})
+	t.Run("Given the OpenAPI specification, When references are resolved, Then every reference must point to a component", func(t *testing.T) {
+		refs := []string{}
+		collectRefs(spec, &refs)
+
+		assert.NotEmpty(t, refs)
+		for _, ref := range refs {
+			parts := strings.Split(strings.TrimPrefix(ref, "#/components/"), "/")
+			assert.Equal(t, 2, len(parts), ref)
+			group, _ := components[parts[0]].(map[string]interface{})
+			assert.Contains(t, group, parts[1], ref)
+		}
+	})
+	t.Run("Given the request and response models, When they are compared with the specification schemas, Then every JSON field must be documented and every documented property must exist", func(t *testing.T) {
+		models := map[string]reflect.Type{
+			"Project":             reflect.TypeOf(matching.Project{}),
+			"City":                reflect.TypeOf(matching.City{}),
+			"CityLocation":        reflect.TypeOf(matching.CityLocation{}),
+			"Location":            reflect.TypeOf(matching.Location{}),
+			"SeniorityRange":      reflect.TypeOf(matching.SeniorityRange{}),
+			"ScoreBreakdown":      reflect.TypeOf(matching.ScoreBreakdown{}),
+			"MatchingParticipant": reflect.TypeOf(matching.MatchingParticipant{}),
+			"ResponseBody":        reflect.TypeOf(ResponseBody{}),
+			"Exclusions":          reflect.TypeOf(matching.Exclusions{}),
+			"QueryError":          reflect.TypeOf(matching.QueryError{}),
+			"Quota":               reflect.TypeOf(matching.Quota{}),
+			"UnmetQuota":          reflect.TypeOf(matching.UnmetQuota{}),
+			"CityAllocation":      reflect.TypeOf(matching.CityAllocation{}),
+			"FeedbackRequest":     reflect.TypeOf(matching.FeedbackRequest{}),
+			"Feedback":            reflect.TypeOf(matching.Feedback{}),
+			"FatigueRule":         reflect.TypeOf(matching.FatigueRule{}),
+			"Participation":       reflect.TypeOf(matching.Participation{}),
+		}
+
+		for name, model := range models {
+			schema, ok := schemas[name].(map[string]interface{})
+			assert.True(t, ok, "schema %s is not documented", name)
+			if ok {
+				assert.Equal(t, jsonFields(model), schemaProperties(schema), "schema %s is out of sync", name)
+			}
+		}
+	})
+	t.Run("Given the specification examples, When they are decoded into the models, Then must only contain known fields", func(t *testing.T) {
+		project := matching.Project{}
+		participants := []matching.MatchingParticipant{}
+		response := ResponseBody{Data: &participants}
+
+		errProject := decodeStrict(t, examples["Project"].(map[string]interface{})["value"], &project)
+		errResponse := decodeStrict(t, examples["MatchingParticipantsResponse"].(map[string]interface{})["value"], &response)
+
+		assert.Nil(t, errProject)
+		assert.NotEmpty(t, project.Cities)
+		assert.Nil(t, errResponse)
+		assert.NotEmpty(t, participants)
+	})
+	t.Run("Given a request to the specification route, When it's served, Then must return the specification as JSON", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		openAPI(recorder, httptest.NewRequest("GET", "/openapi.json", nil), nil)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		assert.JSONEq(t, openAPISpecification, recorder.Body.String())
+	})
+	t.Run("Given a request to the documentation route, When it's served, Then must only load assets of the binary", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+
+		docs(recorder, httptest.NewRequest("GET", "/docs", nil), nil)
+
+		assert.Equal(t, 200, recorder.Code)
+		assert.Equal(t, docsContentSecurityPolicy, recorder.Header().Get("Content-Security-Policy"))
+		assert.Contains(t, recorder.Body.String(), `<script src="/docs.js"></script>`)
+		assert.NotContains(t, recorder.Body.String(), "https://")
+	})
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/julienschmidt/httprouter"
+)
+
+type participationHandler struct {
+	repository matching.ParticipationRepository
+	logger     logging.Logger
+}
+
+func (h *participationHandler) Perform(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	participation := matching.Participation{}
+	logger := h.logger.WithContext(r.Context())
+
+	body, errReadBody := ioutil.ReadAll(r.Body)
+	if errReadBody != nil {
+		logger.Warn("Can't read body", logging.Err(errReadBody))
+		writeResponseWithoutData(w, http.StatusBadRequest, "Can't read body from request")
+		return
+	}
+	if errUnmarshal := json.Unmarshal(body, &participation); errUnmarshal != nil {
+		logger.Warn("Incorrect body", logging.Err(errUnmarshal))
+		writeResponseWithoutData(w, http.StatusUnprocessableEntity, "Incorrect Body")
+		return
+	}
+	if err := participation.Validate(); err != nil {
+		logger.Warn("Invalid participation", logging.Err(err))
+		writeResponseWithoutData(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err := h.repository.Save(participation); err != nil {
+		logger.Error("Can't record participation", logging.Err(err))
+		writeResponseWithoutData(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	logger.Info("Participation recorded", logging.F("outcome", participation.Outcome))
+	writeResponse(w, http.StatusCreated, "Participation recorded", participation)
+}
+
+//NewParticipationHandler returns a Handler that records the participation of participants in projects
+func NewParticipationHandler(repository matching.ParticipationRepository, logger logging.Logger) Handler {
+	return &participationHandler{
+		repository: repository,
+		logger:     logger,
+	}
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/stretchr/testify/assert"
+)
+
+type participationRepository struct {
+	participations []matching.Participation
+}
+
+func (r *participationRepository) Save(participation matching.Participation) error {
+	r.participations = append(r.participations, participation)
+	return nil
+}
+
+func (r *participationRepository) GetByParticipantID(id string) ([]matching.Participation, error) {
+	return r.participations, nil
+}
+
+func TestParticipationHandler(t *testing.T) {
+	repository := &participationRepository{}
+	handler := NewParticipationHandler(repository, logging.New(ioutil.Discard, logging.Options{}))
+	perform := func(body string) (int, ResponseBody) {
+		recorder := httptest.NewRecorder()
+		handler.Perform(recorder, httptest.NewRequest("POST", "/participations", strings.NewReader(body)), nil)
+		response := ResponseBody{}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		return recorder.Code, response
+	}
+
+	t.Run("Given a participation, When it's posted, Then must be recorded", func(t *testing.T) {
+		code, _ := perform(`{"participantId":"1","projectId":"p1","date":"2021-03-01T10:00:00Z","outcome":"completed"}`)
+
+		assert.Equal(t, 201, code)
+		assert.Equal(t, 1, len(repository.participations))
+		assert.Equal(t, matching.OutcomeCompleted, repository.participations[0].Outcome)
+	})
+	t.Run("Given a participation without date and with an unknown outcome, When it's posted, Then must respond every problem", func(t *testing.T) {
+		code, response := perform(`{"participantId":"1","projectId":"p1","outcome":"done"}`)
+
+		assert.Equal(t, 422, code)
+		assert.Equal(t, `Invalid participation: date is required; outcome "done" is unknown, use one of invited, completed, declined, noShow`, response.Message)
+	})
+}
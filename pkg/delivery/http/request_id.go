@@ -0,0 +1,46 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/julienschmidt/httprouter"
+)
+
+//RequestIDHeader is the header that carries the ID of a request, in the request and in its response
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+//correlate returns a handler that stores the request ID in the request context,
+//echoes it in the response and logs every request served by the route
+func correlate(logger logging.Logger, route string, handle httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+		r = r.WithContext(logging.WithRequestID(r.Context(), requestID))
+
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		handle(recorder, r, params)
+		logger.WithContext(r.Context()).Debug("Request served",
+			logging.F("route", route),
+			logging.F("method", r.Method),
+			logging.F("status", recorder.status),
+			logging.F("duration", time.Since(start).String()))
+	}
+}
+
+func newRequestID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelate(t *testing.T) {
+	var requestID string
+	handle := func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		requestID = logging.RequestID(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	t.Run("Given a request with an ID, When it's served, Then the ID must be in the context, the response and the log", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		logger := logging.New(output, logging.Options{Level: logging.DebugLevel, Format: logging.JSONFormat})
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/healthz", nil)
+		request.Header.Set(RequestIDHeader, "request-1")
+
+		correlate(logger, "/healthz", handle)(recorder, request, nil)
+
+		assert.Equal(t, "request-1", requestID)
+		assert.Equal(t, "request-1", recorder.Header().Get(RequestIDHeader))
+		assert.Contains(t, output.String(), `"request_id":"request-1"`)
+		assert.Contains(t, output.String(), `"status":204`)
+	})
+	t.Run("Given a request without an ID, When it's served, Then an ID must be generated", func(t *testing.T) {
+		logger := logging.New(&bytes.Buffer{}, logging.Options{Level: logging.InfoLevel})
+		recorder := httptest.NewRecorder()
+
+		correlate(logger, "/healthz", handle)(recorder, httptest.NewRequest("GET", "/healthz", nil), nil)
+
+		assert.Len(t, requestID, 16)
+		assert.Equal(t, requestID, recorder.Header().Get(RequestIDHeader))
+	})
+}
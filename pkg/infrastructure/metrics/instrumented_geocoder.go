@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/storage"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+type instrumentedGeocoder struct {
+	geocoder  storage.Geocoder
+	collector *PrometheusCollector
+}
+
+func (g *instrumentedGeocoder) GetFormattedAddress(city string, location matching.Location) (string, error) {
+	address, err := g.geocoder.GetFormattedAddress(city, location)
+	g.collector.ObserveGeocoding(err)
+	return address, err
+}
+
+//InstrumentGeocoder returns a Geocoder that records the result of every address resolved by geocoder
+func InstrumentGeocoder(geocoder storage.Geocoder, collector *PrometheusCollector) storage.Geocoder {
+	return &instrumentedGeocoder{
+		geocoder:  geocoder,
+		collector: collector,
+	}
+}
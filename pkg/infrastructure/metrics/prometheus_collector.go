@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/storage"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "matching"
+
+//OtherCity labels the lookups of cities that aren't known, so clients can't create a series per address
+const OtherCity = "other"
+
+//PrometheusCollector records the application metrics in its own Prometheus registry
+type PrometheusCollector struct {
+	registry           *prometheus.Registry
+	requestDuration    *prometheus.HistogramVec
+	resultCount        prometheus.Histogram
+	cityLookupDuration *prometheus.HistogramVec
+	scores             prometheus.Summary
+	geocoding          *prometheus.CounterVec
+	variants           *prometheus.CounterVec
+	feedback           *prometheus.CounterVec
+	knownCity          func(city string) (string, bool)
+}
+
+//ObserveRequest records the duration of an HTTP request to a route
+func (c *PrometheusCollector) ObserveRequest(route string, method string, code int, duration time.Duration) {
+	c.requestDuration.WithLabelValues(route, method, strconv.Itoa(code)).Observe(duration.Seconds())
+}
+
+//ObserveCityLookup records the duration of looking for the participants of a project city. Cities that
+//aren't known are labelled as OtherCity
+func (c *PrometheusCollector) ObserveCityLookup(city string, duration time.Duration) {
+	label := OtherCity
+	if c.knownCity != nil {
+		if known, ok := c.knownCity(city); ok {
+			label = known
+		}
+	}
+	c.cityLookupDuration.WithLabelValues(label).Observe(duration.Seconds())
+}
+
+//ObserveResults records the amount of matching participants found for a project
+func (c *PrometheusCollector) ObserveResults(count int) {
+	c.resultCount.Observe(float64(count))
+}
+
+//ObserveScore records the matching score of a participant
+func (c *PrometheusCollector) ObserveScore(score float64) {
+	c.scores.Observe(score)
+}
+
+//ObserveVariant records a project scored by an experiment variant
+func (c *PrometheusCollector) ObserveVariant(variant string) {
+	c.variants.WithLabelValues(variant).Inc()
+}
+
+//ObserveFeedback records a recruiter decision about a participant scored by an experiment variant
+func (c *PrometheusCollector) ObserveFeedback(variant string, accepted bool) {
+	decision := "rejected"
+	if accepted {
+		decision = "accepted"
+	}
+	c.feedback.WithLabelValues(variant, decision).Inc()
+}
+
+//ObserveGeocoding records the result of resolving the address of a participant
+func (c *PrometheusCollector) ObserveGeocoding(err error) {
+	result := "success"
+	if err == storage.ErrAddressNotFound {
+		result = "not_found"
+	} else if err != nil {
+		result = "failure"
+	}
+	c.geocoding.WithLabelValues(result).Inc()
+}
+
+//RegisterRepositorySize exposes the amount of participants returned by size as a gauge
+func (c *PrometheusCollector) RegisterRepositorySize(size func() int) {
+	c.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "repository_participants",
+		Help:      "Amount of participants loaded in the repository.",
+	}, func() float64 {
+		return float64(size())
+	}))
+}
+
+//RegisterKnownCities labels city lookups with the city returned by known, and the cities it doesn't know
+//as OtherCity. Every city is OtherCity until it's registered
+func (c *PrometheusCollector) RegisterKnownCities(known func(city string) (string, bool)) {
+	c.knownCity = known
+}
+
+//Handler returns the HTTP handler that exposes the metrics to Prometheus
+func (c *PrometheusCollector) Handler() http.Handler {
+	return promhttp.HandlerFor(c.registry, promhttp.HandlerOpts{})
+}
+
+//NewPrometheusCollector returns a PrometheusCollector with its metrics registered,
+//along with the Go runtime and process metrics
+func NewPrometheusCollector() *PrometheusCollector {
+	c := &PrometheusCollector{
+		registry: prometheus.NewRegistry(),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "code"}),
+		resultCount: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "results",
+			Help:      "Amount of matching participants found for a project.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+		}),
+		cityLookupDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "city_lookup_duration_seconds",
+			Help:      "Duration of looking for the participants of a project city.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 8),
+		}, []string{"city"}),
+		scores: prometheus.NewSummary(prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "participant_score",
+			Help:       "Matching score of the participants found for projects.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		}),
+		geocoding: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "geocoding_total",
+			Help:      "Participant addresses resolved while loading the repository, by result.",
+		}, []string{"result"}),
+		variants: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "experiment_assignments_total",
+			Help:      "Projects scored by every experiment variant.",
+		}, []string{"variant"}),
+		feedback: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "feedback_total",
+			Help:      "Recruiter decisions about participants, by experiment variant and decision.",
+		}, []string{"variant", "decision"}),
+	}
+	c.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		c.requestDuration,
+		c.resultCount,
+		c.cityLookupDuration,
+		c.scores,
+		c.geocoding,
+		c.variants,
+		c.feedback,
+	)
+	return c
+}
+
+var _ matching.Metrics = &PrometheusCollector{}
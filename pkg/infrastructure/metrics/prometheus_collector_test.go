@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/infrastructure/storage"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeGeocoder struct {
+	err error
+}
+
+func (g fakeGeocoder) GetFormattedAddress(city string, location matching.Location) (string, error) {
+	return city, g.err
+}
+
+func TestPrometheusCollector(t *testing.T) {
+	t.Run("Given an instrumented geocoder, When addresses are resolved, Then must count them by result", func(t *testing.T) {
+		collector := NewPrometheusCollector()
+
+		InstrumentGeocoder(fakeGeocoder{}, collector).GetFormattedAddress("New York, NY, USA", matching.Location{})
+		InstrumentGeocoder(fakeGeocoder{err: storage.ErrAddressNotFound}, collector).GetFormattedAddress("", matching.Location{})
+		InstrumentGeocoder(fakeGeocoder{err: errors.New("timeout")}, collector).GetFormattedAddress("", matching.Location{})
+
+		assert.Equal(t, 1.0, testutil.ToFloat64(collector.geocoding.WithLabelValues("success")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(collector.geocoding.WithLabelValues("not_found")))
+		assert.Equal(t, 1.0, testutil.ToFloat64(collector.geocoding.WithLabelValues("failure")))
+	})
+	t.Run("Given matching measures, When metrics are scraped, Then must expose every matching metric", func(t *testing.T) {
+		collector := NewPrometheusCollector()
+		collector.RegisterRepositorySize(func() int { return 42 })
+		collector.RegisterKnownCities(func(city string) (string, bool) {
+			return "New York, NY, USA", strings.EqualFold(city, "New York, NY, USA")
+		})
+		collector.ObserveRequest("/matching/", "GET", 200, 30*time.Millisecond)
+		collector.ObserveCityLookup("new york, ny, usa", time.Millisecond)
+		collector.ObserveCityLookup("Atlantis", time.Millisecond)
+		collector.ObserveResults(23)
+		collector.ObserveScore(2.5)
+		collector.ObserveGeocoding(nil)
+		collector.ObserveVariant("control")
+		collector.ObserveFeedback("control", true)
+		recorder := httptest.NewRecorder()
+
+		collector.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+		body := recorder.Body.String()
+		assert.Contains(t, body, `matching_http_request_duration_seconds_count{code="200",method="GET",route="/matching/"} 1`)
+		assert.Contains(t, body, `matching_city_lookup_duration_seconds_count{city="New York, NY, USA"} 1`)
+		assert.Contains(t, body, `matching_city_lookup_duration_seconds_count{city="other"} 1`)
+		assert.Contains(t, body, "matching_results_sum 23")
+		assert.Contains(t, body, "matching_participant_score_sum 2.5")
+		assert.Contains(t, body, "matching_repository_participants 42")
+		assert.Contains(t, body, `matching_geocoding_total{result="success"} 1`)
+		assert.Contains(t, body, `matching_experiment_assignments_total{variant="control"} 1`)
+		assert.Contains(t, body, `matching_feedback_total{decision="accepted",variant="control"} 1`)
+	})
+}
@@ -1,14 +1,22 @@
 package storage
 
 import (
+	"os"
 	"testing"
+	"time"
 
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCsvParticipantsRepository(t *testing.T) {
+	if os.Getenv(MapsAPIKeyEnv) == "" {
+		t.Skip(MapsAPIKeyEnv + " is required to resolve addresses with Google Geocoding API")
+	}
 	csvPath := "respondents_data_test.csv"
-	repository := NewCsvParticipantsRepository(csvPath)
+	repository, err := NewCsvParticipantsRepository(csvPath)
+	assert.Nil(t, err)
 
 	t.Run("Given a new initialization, When repository is created, Then csv file fullfil the repository", func(t *testing.T) {
 		assert.NotEqual(t, 0, len(repository.Participants))
@@ -20,3 +28,147 @@ func TestCsvParticipantsRepository(t *testing.T) {
 		assert.NotEqual(t, 23, len(participants))
 	})
 }
+
+func TestCsvParticipantsRepositoryWithCsvCityGeocoder(t *testing.T) {
+	csvPath := "respondents_data_test.csv"
+	repository, err := NewCsvParticipantsRepositoryWithGeocoder(csvPath, NewCsvCityGeocoder(), logging.Default())
+	assert.Nil(t, err)
+
+	t.Run("Given a csv city geocoder, When repository is created, Then participants must use the city from the csv file as formattedAddress", func(t *testing.T) {
+		participants, err := repository.GetByFormattedAddress("Brooklyn, NY, USA")
+
+		assert.Nil(t, err)
+		assert.NotEqual(t, 0, len(participants))
+		assert.Equal(t, "Jillian", participants[0].Name)
+	})
+	t.Run("Given a csv city geocoder, When an address is checked, Then must only know the addresses of the participants ignoring case", func(t *testing.T) {
+		known, ok := repository.KnownAddress("brooklyn, ny, usa")
+		_, unknown := repository.KnownAddress("Atlantis")
+
+		assert.True(t, ok)
+		assert.Equal(t, "Brooklyn, NY, USA", known)
+		assert.False(t, unknown)
+	})
+	t.Run("Given a csv file, When repository is loaded twice, Then participants must keep the same IDs", func(t *testing.T) {
+		reloaded, _ := NewCsvParticipantsRepositoryWithGeocoder(csvPath, NewCsvCityGeocoder(), logging.Default())
+
+		assert.Equal(t, len(repository.Participants), len(reloaded.Participants))
+		for i := range repository.Participants {
+			assert.NotEqual(t, "", reloaded.Participants[i].ID)
+			assert.Equal(t, repository.Participants[i].ID, reloaded.Participants[i].ID)
+		}
+	})
+	t.Run("Given industries with commas in the csv file, When repository is created, Then participants must have the whole industry names", func(t *testing.T) {
+		participants, err := repository.GetByFormattedAddress("Dallas, TX, USA")
+
+		assert.Nil(t, err)
+		for _, participant := range participants {
+			if participant.Name == "Lindsay" {
+				assert.Equal(t, []string{"Computer Software", "Health, Wellness and Fitness"}, participant.Industry)
+			}
+		}
+	})
+	t.Run("Given job titles in the csv file, When repository is created, Then participants must have the seniority of their job titles", func(t *testing.T) {
+		participants, err := repository.GetByFormattedAddress("Dallas, TX, USA")
+
+		assert.Nil(t, err)
+		for _, participant := range participants {
+			assert.Equal(t, matching.ParseSeniorityLevel(participant.JobTitle), participant.Seniority, participant.JobTitle)
+			if participant.Name == "Lindsay" {
+				assert.Equal(t, matching.SeniorityManager, participant.Seniority)
+			}
+		}
+	})
+}
+
+func TestAsyncCsvParticipantsRepository(t *testing.T) {
+	t.Run("Given an async repository, When participants are loaded, Then must report ready status and return participants", func(t *testing.T) {
+		repository := NewAsyncCsvParticipantsRepository("respondents_data_test.csv", NewCsvCityGeocoder(), logging.Default())
+
+		assert.Eventually(t, func() bool {
+			status, _ := repository.Status()
+			return status == StatusReady
+		}, time.Second, 10*time.Millisecond)
+		participants, err := repository.GetByFormattedAddress("Brooklyn, NY, USA")
+		assert.Nil(t, err)
+		assert.NotEqual(t, 0, len(participants))
+	})
+	t.Run("Given an async repository, When the csv file can't be loaded, Then must report failed status and return the load error", func(t *testing.T) {
+		repository := NewAsyncCsvParticipantsRepository("missing.csv", NewCsvCityGeocoder(), logging.Default())
+
+		assert.Eventually(t, func() bool {
+			status, _ := repository.Status()
+			return status == StatusFailed
+		}, time.Second, 10*time.Millisecond)
+		_, err := repository.GetByFormattedAddress("Brooklyn, NY, USA")
+		assert.NotNil(t, err)
+	})
+	t.Run("Given a repository that is still loading, When participants are requested, Then must return a loading error", func(t *testing.T) {
+		repository := &CsvParticipantRepository{status: StatusLoading}
+
+		_, err := repository.GetByFormattedAddress("Brooklyn, NY, USA")
+
+		assert.Equal(t, matching.ErrParticipantsLoading, err)
+	})
+	t.Run("Given a csv file with an education column, When repository is created, Then participants must have their education", func(t *testing.T) {
+		withEducation, _ := NewCsvParticipantsRepositoryWithGeocoder("testdata/respondents_with_education.csv", NewCsvCityGeocoder(), logging.Default())
+
+		newYork, _ := withEducation.GetByFormattedAddress("New York, NY, USA")
+		brooklyn, _ := withEducation.GetByFormattedAddress("Brooklyn, NY, USA")
+
+		assert.Equal(t, "Bachelor's Degree", newYork[0].Education)
+		assert.Equal(t, "", brooklyn[0].Education)
+	})
+	t.Run("Given the same participant with another profile, When repository is created, Then the participant must keep the same ID", func(t *testing.T) {
+		original, _ := NewCsvParticipantsRepositoryWithGeocoder("respondents_data_test.csv", NewCsvCityGeocoder(), logging.Default())
+		edited, _ := NewCsvParticipantsRepositoryWithGeocoder("testdata/respondents_with_education.csv", NewCsvCityGeocoder(), logging.Default())
+
+		before, _ := original.GetByFormattedAddress("New York, NY, USA")
+		after, _ := edited.GetByFormattedAddress("New York, NY, USA")
+
+		assert.Equal(t, "Jefferson", before[0].Name)
+		assert.NotEqual(t, before[0].Industry, after[0].Industry)
+		assert.Equal(t, before[0].ID, after[0].ID)
+	})
+	t.Run("Given a csv file with an id column, When repository is created, Then participants must have the IDs of the file", func(t *testing.T) {
+		withIDs, err := NewCsvParticipantsRepositoryWithGeocoder("testdata/respondents_with_ids.csv", NewCsvCityGeocoder(), logging.Default())
+
+		assert.Nil(t, err)
+		assert.Equal(t, "crm-1042", withIDs.Participants[0].ID)
+		assert.Equal(t, "crm-1043", withIDs.Participants[1].ID)
+	})
+	t.Run("Given a csv file with a repeated id, When repository is created, Then must return the line of the repeated id", func(t *testing.T) {
+		_, err := NewCsvParticipantsRepositoryWithGeocoder("testdata/respondents_with_repeated_ids.csv", NewCsvCityGeocoder(), logging.Default())
+
+		assert.Contains(t, err.Error(), `line 3: participant id "crm-1042" is repeated`)
+	})
+	t.Run("Given an async repository, When participants are loaded, Then must have an index of every participant", func(t *testing.T) {
+		repository := NewAsyncCsvParticipantsRepository("respondents_data_test.csv", NewCsvCityGeocoder(), logging.Default())
+		assert.Nil(t, (&CsvParticipantRepository{status: StatusLoading}).Index())
+
+		assert.Eventually(t, func() bool {
+			status, _ := repository.Status()
+			return status == StatusReady
+		}, time.Second, 10*time.Millisecond)
+		index := repository.Index()
+		assert.NotNil(t, index)
+		assert.Equal(t, repository.Size(), index.Documents())
+		assert.NotEqual(t, 0, index.DocumentFrequency("developer"))
+	})
+	t.Run("Given an async repository, When participants are requested by ID, Then must return the participant or not found", func(t *testing.T) {
+		repository := NewAsyncCsvParticipantsRepository("respondents_data_test.csv", NewCsvCityGeocoder(), logging.Default())
+		_, err := (&CsvParticipantRepository{status: StatusLoading}).GetByID("1")
+		assert.Equal(t, matching.ErrParticipantsLoading, err)
+
+		assert.Eventually(t, func() bool {
+			status, _ := repository.Status()
+			return status == StatusReady
+		}, time.Second, 10*time.Millisecond)
+		participants, _ := repository.GetByFormattedAddress("Brooklyn, NY, USA")
+		participant, err := repository.GetByID(participants[0].ID)
+		assert.Nil(t, err)
+		assert.Equal(t, participants[0], participant)
+		_, err = repository.GetByID("missing")
+		assert.Equal(t, matching.ErrParticipantNotFound, err)
+	})
+}
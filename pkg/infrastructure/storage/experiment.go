@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"gopkg.in/yaml.v3"
+)
+
+type experimentFile struct {
+	Name     string `yaml:"name"`
+	Variants []struct {
+		Name         string       `yaml:"name"`
+		Share        float64      `yaml:"share"`
+		RankingModel string       `yaml:"rankingModel"`
+		Weights      *weightsFile `yaml:"weights"`
+	} `yaml:"variants"`
+}
+
+type weightsFile struct {
+	Industry  float64 `yaml:"industry"`
+	JobTitle  float64 `yaml:"jobTitle"`
+	Seniority float64 `yaml:"seniority"`
+	Gender    float64 `yaml:"gender"`
+	Education float64 `yaml:"education"`
+	Relevance float64 `yaml:"relevance"`
+}
+
+//LoadExperiment returns the Experiment described by the YAML file at path. Every variant scores with the given
+//options, and its own weights or ranking model, which path is relative to the experiment file
+func LoadExperiment(path string, options ...matching.ScoreOption) (*matching.Experiment, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := experimentFile{}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	if file.Name == "" {
+		return nil, fmt.Errorf("%s: %s", path, errors.New("Invalid experiment: name is required"))
+	}
+
+	variants := []matching.Variant{}
+	for _, variant := range file.Variants {
+		variantOptions := append([]matching.ScoreOption{}, options...)
+		if variant.RankingModel != "" {
+			modelPath := variant.RankingModel
+			if !filepath.IsAbs(modelPath) {
+				modelPath = filepath.Join(filepath.Dir(path), modelPath)
+			}
+			model, err := LoadRankingModel(modelPath)
+			if err != nil {
+				return nil, err
+			}
+			variantOptions = append(variantOptions, matching.WithRankingModel(model))
+		}
+		if variant.Weights != nil {
+			variantOptions = append(variantOptions, matching.WithScoreWeights(matching.ScoreWeights(*variant.Weights)))
+		}
+		variants = append(variants, matching.Variant{
+			Name:  variant.Name,
+			Share: variant.Share,
+			Score: matching.NewScoreService(variantOptions...),
+		})
+	}
+	experiment, err := matching.NewExperiment(file.Name, variants...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	return experiment, nil
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadExperiment(t *testing.T) {
+	t.Run("Given an experiment file, When it's loaded, Then every variant must score with its weights or ranking model", func(t *testing.T) {
+		experiment, err := LoadExperiment("testdata/experiment.yaml")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "learned-weights", experiment.Name)
+		variants := experiment.Variants()
+		assert.Equal(t, 3, len(variants))
+		project := matching.Project{ProfessionalIndustry: []string{"Banking"}, ProfessionalJobTitles: []string{"Cashier"}}
+		banker := matching.Participant{JobTitle: "Java Developer", Industry: []string{"Banking"}}
+		assert.InDelta(t, 0.5, variants[0].Score.GetMatchingScore(project, banker), 0.0001)
+		assert.Equal(t, 0.0, variants[1].Score.GetMatchingScore(project, banker))
+		assert.InDelta(t, 0.2, variants[2].Score.GetMatchingScore(project, banker), 0.0001)
+		assert.Equal(t, 2.0, variants[2].Share)
+	})
+	t.Run("Given an invalid experiment file, When it's loaded, Then must return the problems found", func(t *testing.T) {
+		_, err := LoadExperiment("testdata/invalid_experiment.yaml")
+
+		assert.EqualError(t, err, "testdata/invalid_experiment.yaml: Invalid experiment: variants[0] share must be greater than 0")
+	})
+}
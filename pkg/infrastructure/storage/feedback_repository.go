@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+//JSONLinesFeedbackRepository represents a FeedbackRepository that appends every Feedback to a file as a JSON line
+type JSONLinesFeedbackRepository struct {
+	path string
+	mu   sync.Mutex
+}
+
+//Save appends the feedback to the file, creating it when it doesn't exist
+func (r *JSONLinesFeedbackRepository) Save(feedback matching.Feedback) error {
+	line, err := json.Marshal(feedback)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+//NewJSONLinesFeedbackRepository returns a new JSONLinesFeedbackRepository that appends feedback to the file at path
+func NewJSONLinesFeedbackRepository(path string) *JSONLinesFeedbackRepository {
+	return &JSONLinesFeedbackRepository{path: path}
+}
+
+//LoadFeedback returns the Feedback of the JSON lines file at path, skipping empty lines
+func LoadFeedback(path string) ([]matching.Feedback, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	feedback := []matching.Feedback{}
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		f := matching.Feedback{}
+		if err := json.Unmarshal(scanner.Bytes(), &f); err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", path, line, err)
+		}
+		feedback = append(feedback, f)
+	}
+	return feedback, scanner.Err()
+}
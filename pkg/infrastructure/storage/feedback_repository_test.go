@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedbackRepository(t *testing.T) {
+	dir, err := ioutil.TempDir("", "feedback")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("Given feedback saved to a file, When the file is loaded, Then must return every feedback in order", func(t *testing.T) {
+		path := filepath.Join(dir, "feedback.jsonl")
+		repository := NewJSONLinesFeedbackRepository(path)
+		feedback := []matching.Feedback{
+			{ProjectID: "p1", ParticipantID: "1", Accepted: true, Features: matching.ScoreBreakdown{JobTitle: 1}, RecordedAt: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)},
+			{ProjectID: "p1", ParticipantID: "2", Features: matching.ScoreBreakdown{Industry: 0.5}, RecordedAt: time.Date(2021, 1, 2, 3, 5, 5, 0, time.UTC)},
+		}
+
+		for _, f := range feedback {
+			assert.Nil(t, repository.Save(f))
+		}
+		loaded, err := LoadFeedback(path)
+
+		assert.Nil(t, err)
+		assert.Equal(t, feedback, loaded)
+	})
+	t.Run("Given a feedback file with an invalid line, When it's loaded, Then must return the line that can't be read", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.jsonl")
+		assert.Nil(t, ioutil.WriteFile(path, []byte("{\"participantId\":\"1\"}\n\nnot json\n"), 0644))
+
+		_, err := LoadFeedback(path)
+
+		assert.Contains(t, err.Error(), "invalid.jsonl:3: ")
+	})
+	t.Run("Given a ranking model saved to a file, When it's loaded, Then must return the same model", func(t *testing.T) {
+		path := filepath.Join(dir, "model.json")
+		model := matching.RankingModel{Weights: matching.ScoreWeights{JobTitle: 1.5, Industry: 0.25}, Bias: -1, Examples: 10, Accuracy: 0.9}
+
+		assert.Nil(t, SaveRankingModel(path, model))
+		loaded, err := LoadRankingModel(path)
+
+		assert.Nil(t, err)
+		assert.Equal(t, model, loaded)
+	})
+}
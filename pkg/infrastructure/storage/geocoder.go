@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"googlemaps.github.io/maps"
+)
+
+//ErrAddressNotFound is retrived when a Geocoder can't find an address for a participant
+var ErrAddressNotFound = errors.New("Not found address for location")
+
+//Geocoder resolves the normalized formatted address of a participant, using the city
+//written in the csv file and its location
+type Geocoder interface {
+	GetFormattedAddress(city string, location matching.Location) (string, error)
+}
+
+type googleMapsGeocoder struct {
+	client *maps.Client
+}
+
+func (g *googleMapsGeocoder) GetFormattedAddress(_ string, location matching.Location) (string, error) {
+	r := &maps.GeocodingRequest{
+		LatLng:     &maps.LatLng{Lat: location.Latitude, Lng: location.Longitude},
+		ResultType: []string{"locality"},
+	}
+
+	resp, errReverseGeocode := g.client.ReverseGeocode(context.Background(), r)
+	if errReverseGeocode != nil {
+		return "", errReverseGeocode
+	}
+
+	if len(resp) == 0 {
+		return "", ErrAddressNotFound
+	}
+
+	return resp[0].FormattedAddress, nil
+}
+
+//NewGoogleMapsGeocoder returns a Geocoder that calls Google Geocoding API with the location of every participant
+func NewGoogleMapsGeocoder(key string) (Geocoder, error) {
+	client, err := maps.NewClient(maps.WithAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+	return &googleMapsGeocoder{
+		client: client,
+	}, nil
+}
+
+type csvCityGeocoder struct {
+}
+
+func (g *csvCityGeocoder) GetFormattedAddress(city string, _ matching.Location) (string, error) {
+	address := strings.TrimSpace(city)
+	if address == "" {
+		return "", ErrAddressNotFound
+	}
+	return address, nil
+}
+
+//NewCsvCityGeocoder returns a Geocoder that trusts the city written in the csv file.
+//It doesn't need network access, but cities are not normalized
+func NewCsvCityGeocoder() Geocoder {
+	return &csvCityGeocoder{}
+}
+
+//NewGeocoder returns the Geocoder with the given name: google or csv. The key is only used by google
+func NewGeocoder(name string, key string) (Geocoder, error) {
+	switch name {
+	case "google":
+		return NewGoogleMapsGeocoder(key)
+	case "csv":
+		return NewCsvCityGeocoder(), nil
+	}
+	return nil, fmt.Errorf("Unknown geocoder %q", name)
+}
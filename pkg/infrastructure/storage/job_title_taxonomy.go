@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"gopkg.in/yaml.v3"
+)
+
+type taxonomyFile struct {
+	FamilyCredit float64 `yaml:"familyCredit"`
+	Roles        []struct {
+		Name     string   `yaml:"name"`
+		Family   string   `yaml:"family"`
+		Synonyms []string `yaml:"synonyms"`
+	} `yaml:"roles"`
+}
+
+//LoadJobTitleTaxonomy returns the JobTitleTaxonomy described by the YAML file at path
+func LoadJobTitleTaxonomy(path string) (*matching.JobTitleTaxonomy, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := taxonomyFile{}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	problems := []string{}
+	if file.FamilyCredit < 0 || file.FamilyCredit > 1 {
+		problems = append(problems, "familyCredit must be between 0 and 1")
+	}
+	roles := []matching.Role{}
+	names := map[string]bool{}
+	for i, role := range file.Roles {
+		name := strings.ToLower(strings.TrimSpace(role.Name))
+		if name == "" {
+			problems = append(problems, fmt.Sprintf("roles[%d] name is required", i))
+		} else if names[name] {
+			problems = append(problems, fmt.Sprintf("roles[%d] %s is repeated", i, role.Name))
+		}
+		names[name] = true
+		roles = append(roles, matching.Role{
+			Name:     role.Name,
+			Family:   role.Family,
+			Synonyms: role.Synonyms,
+		})
+	}
+	if len(roles) == 0 {
+		problems = append(problems, "at least one role is required")
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s: invalid taxonomy: %s", path, strings.Join(problems, "; "))
+	}
+	return matching.NewJobTitleTaxonomy(roles, file.FamilyCredit), nil
+}
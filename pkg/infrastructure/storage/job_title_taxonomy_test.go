@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadJobTitleTaxonomy(t *testing.T) {
+	t.Run("Given a taxonomy file, When it's loaded, Then must resolve titles to its roles and use its family credit", func(t *testing.T) {
+		taxonomy, err := LoadJobTitleTaxonomy("testdata/job_title_taxonomy.yaml")
+
+		assert.Nil(t, err)
+		role, ok := taxonomy.Resolve("Senior Dev")
+		assert.True(t, ok)
+		assert.Equal(t, "Software Engineer", role.Name)
+		assert.Equal(t, 0.25, taxonomy.Similarity("SWE", "Java Engineer"))
+	})
+	t.Run("Given an invalid taxonomy file, When it's loaded, Then must return every problem found", func(t *testing.T) {
+		_, err := LoadJobTitleTaxonomy("testdata/invalid_job_title_taxonomy.yaml")
+
+		assert.Equal(t, "testdata/invalid_job_title_taxonomy.yaml: invalid taxonomy: familyCredit must be between 0 and 1; roles[1] software engineer is repeated; roles[2] name is required", err.Error())
+	})
+}
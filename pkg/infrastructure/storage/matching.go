@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"github.com/carlos-rodrigo/matching-app/pkg/config"
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+//Matching represents the matching Action built from a Config, with the services the servers share with it
+type Matching struct {
+	Action     matching.Action
+	Score      matching.ScoreService
+	Experiment *matching.Experiment
+	//History is the participation history of the Config, nil when it has no participation file
+	History matching.ParticipationRepository
+}
+
+//LoadMatching returns the Matching of the Config for the participants of the repository, so every server ranks
+//a project the same way. The Action records its metrics with the given Metrics
+func LoadMatching(cfg config.Config, repo *CsvParticipantRepository, metrics matching.Metrics, logger logging.Logger) (Matching, error) {
+	scoreOptions, err := LoadScoreOptions(cfg, repo)
+	if err != nil {
+		return Matching{}, err
+	}
+	m := Matching{Score: matching.NewScoreService(scoreOptions...)}
+	options := []matching.ActionOption{
+		matching.WithMaxDistance(cfg.MaxDistance),
+		matching.WithMetrics(metrics),
+		matching.WithLogger(logger),
+	}
+	if cfg.Experiment != "" {
+		m.Experiment, err = LoadExperiment(cfg.Experiment, scoreOptions...)
+		if err != nil {
+			return Matching{}, err
+		}
+		options = append(options, matching.WithExperiment(m.Experiment))
+	}
+	if cfg.Allocate {
+		options = append(options, matching.WithAllocation(matching.DefaultAllocationCosts))
+	}
+	if cfg.ParticipationFile != "" {
+		history, err := NewJSONLinesParticipationRepository(cfg.ParticipationFile)
+		if err != nil {
+			return Matching{}, err
+		}
+		m.History = history
+		options = append(options, matching.WithParticipationHistory(history))
+	}
+	m.Action = matching.NewMatchingParticipantsAction(repo, matching.NewDistanceService(), m.Score, options...)
+	return m, nil
+}
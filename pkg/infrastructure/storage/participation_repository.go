@@ -0,0 +1,137 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+//participationRefreshInterval is how often the participation file is checked for lines appended by other processes
+const participationRefreshInterval = time.Second
+
+//JSONLinesParticipationRepository represents a ParticipationRepository that appends every Participation to a file
+//as a JSON line, and keeps the participations of every participant in memory. Lines appended to the file by other
+//processes, like the HTTP server when this is the gRPC one, are read at most a second later
+type JSONLinesParticipationRepository struct {
+	path            string
+	refreshInterval time.Duration
+
+	mu             sync.RWMutex
+	participations map[string][]matching.Participation
+	offset         int64
+	lines          int
+	refreshed      time.Time
+}
+
+//Save appends the participation to the file, creating it when it doesn't exist
+func (r *JSONLinesParticipationRepository) Save(participation matching.Participation) error {
+	line, err := json.Marshal(participation)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return r.read()
+}
+
+//GetByParticipantID returns the participations of the participant with the given ID, in the order they were saved
+func (r *JSONLinesParticipationRepository) GetByParticipantID(id string) ([]matching.Participation, error) {
+	if err := r.refresh(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]matching.Participation{}, r.participations[id]...), nil
+}
+
+//refresh reads the lines appended to the file when it wasn't checked in the last refreshInterval
+func (r *JSONLinesParticipationRepository) refresh() error {
+	r.mu.RLock()
+	stale := time.Since(r.refreshed) >= r.refreshInterval
+	r.mu.RUnlock()
+	if !stale {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.refreshed) < r.refreshInterval {
+		return nil
+	}
+	r.refreshed = time.Now()
+	return r.read()
+}
+
+//read adds the participations of the lines after the offset of the file. A last line without a newline
+//is still being written, so it's left for the next read
+func (r *JSONLinesParticipationRepository) read() error {
+	file, err := os.Open(r.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := file.Seek(r.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		r.offset += int64(len(line))
+		r.lines++
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		participation := matching.Participation{}
+		if err := json.Unmarshal(line, &participation); err != nil {
+			return fmt.Errorf("%s:%d: %s", r.path, r.lines, err)
+		}
+		r.participations[participation.ParticipantID] = append(r.participations[participation.ParticipantID], participation)
+	}
+}
+
+//NewJSONLinesParticipationRepository returns a new JSONLinesParticipationRepository with the participations of the
+//file at path, which is created by the first Save when it doesn't exist
+func NewJSONLinesParticipationRepository(path string) (*JSONLinesParticipationRepository, error) {
+	r := &JSONLinesParticipationRepository{
+		path:            path,
+		refreshInterval: participationRefreshInterval,
+		participations:  map[string][]matching.Participation{},
+		refreshed:       time.Now(),
+	}
+	if err := r.read(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+var _ matching.ParticipationRepository = &JSONLinesParticipationRepository{}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParticipationRepository(t *testing.T) {
+	dir, err := ioutil.TempDir("", "participations")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("Given participations saved to a file, When the repository is created again, Then must return the participations of every participant", func(t *testing.T) {
+		path := filepath.Join(dir, "participations.jsonl")
+		repository, err := NewJSONLinesParticipationRepository(path)
+		assert.Nil(t, err)
+		participations := []matching.Participation{
+			{ParticipantID: "1", ProjectID: "p1", Date: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), Outcome: matching.OutcomeCompleted},
+			{ParticipantID: "2", ProjectID: "p1", Date: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), Outcome: matching.OutcomeDeclined},
+			{ParticipantID: "1", ProjectID: "p2", Date: time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC), Outcome: matching.OutcomeInvited},
+		}
+
+		for _, participation := range participations {
+			assert.Nil(t, repository.Save(participation))
+		}
+		reloaded, err := NewJSONLinesParticipationRepository(path)
+		assert.Nil(t, err)
+		first, _ := reloaded.GetByParticipantID("1")
+		unknown, _ := reloaded.GetByParticipantID("3")
+
+		assert.Equal(t, []matching.Participation{participations[0], participations[2]}, first)
+		assert.Equal(t, []matching.Participation{}, unknown)
+	})
+	t.Run("Given a participations file with an invalid line, When the repository is created, Then must return the line that can't be read", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.jsonl")
+		assert.Nil(t, ioutil.WriteFile(path, []byte("{\"participantId\":\"1\"}\nnot json\n"), 0644))
+
+		_, err := NewJSONLinesParticipationRepository(path)
+
+		assert.Contains(t, err.Error(), "invalid.jsonl:2: ")
+	})
+	t.Run("Given two repositories of the same file, When one of them saves a participation, Then the other must return it once it refreshes", func(t *testing.T) {
+		path := filepath.Join(dir, "shared.jsonl")
+		writer, err := NewJSONLinesParticipationRepository(path)
+		assert.Nil(t, err)
+		reader, err := NewJSONLinesParticipationRepository(path)
+		assert.Nil(t, err)
+		participation := matching.Participation{ParticipantID: "1", ProjectID: "p1", Date: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), Outcome: matching.OutcomeInvited}
+
+		assert.Nil(t, writer.Save(participation))
+		cached, _ := reader.GetByParticipantID("1")
+		reader.refreshInterval = 0
+		refreshed, err := reader.GetByParticipantID("1")
+
+		assert.Equal(t, []matching.Participation{}, cached)
+		assert.Nil(t, err)
+		assert.Equal(t, []matching.Participation{participation}, refreshed)
+	})
+}
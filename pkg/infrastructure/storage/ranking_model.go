@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+//LoadRankingModel returns the RankingModel of the JSON file at path
+func LoadRankingModel(path string) (matching.RankingModel, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return matching.RankingModel{}, err
+	}
+	model := matching.RankingModel{}
+	if err := json.Unmarshal(content, &model); err != nil {
+		return matching.RankingModel{}, fmt.Errorf("%s: %s", path, err)
+	}
+	return model, nil
+}
+
+//SaveRankingModel writes the RankingModel to the file at path as indented JSON
+func SaveRankingModel(path string, model matching.RankingModel) error {
+	content, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(content, '\n'), 0644)
+}
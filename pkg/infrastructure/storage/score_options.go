@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"github.com/carlos-rodrigo/matching-app/pkg/config"
+	"github.com/carlos-rodrigo/matching-app/pkg/matching"
+)
+
+//LoadScoreOptions returns the ScoreOptions of the job title taxonomy and ranking model files of the Config,
+//ranking participants by relevance with the index when the Config enables it
+func LoadScoreOptions(cfg config.Config, index matching.ParticipantIndex) ([]matching.ScoreOption, error) {
+	options := []matching.ScoreOption{}
+	if cfg.Relevance {
+		options = append(options, matching.WithRelevanceIndex(index))
+	}
+	if cfg.JobTitleTaxonomy != "" {
+		taxonomy, err := LoadJobTitleTaxonomy(cfg.JobTitleTaxonomy)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, matching.WithJobTitleTaxonomy(taxonomy))
+	}
+	if cfg.RankingModel != "" {
+		model, err := LoadRankingModel(cfg.RankingModel)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, matching.WithRankingModel(model))
+	}
+	return options, nil
+}
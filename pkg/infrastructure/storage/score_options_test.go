@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadScoreOptions(t *testing.T) {
+	index := &CsvParticipantRepository{}
+
+	t.Run("Given a default Config, When score options are loaded, Then must not change the score service", func(t *testing.T) {
+		options, err := LoadScoreOptions(config.Config{}, index)
+
+		assert.Nil(t, err)
+		assert.Len(t, options, 0)
+	})
+	t.Run("Given a Config with relevance, a taxonomy and a ranking model, When score options are loaded, Then must score with all of them", func(t *testing.T) {
+		options, err := LoadScoreOptions(config.Config{Relevance: true, JobTitleTaxonomy: "testdata/job_title_taxonomy.yaml", RankingModel: "testdata/ranking_model.json"}, index)
+
+		assert.Nil(t, err)
+		assert.Len(t, options, 3)
+	})
+	t.Run("Given a Config with a missing taxonomy file, When score options are loaded, Then must return an error", func(t *testing.T) {
+		_, err := LoadScoreOptions(config.Config{JobTitleTaxonomy: "testdata/missing.yaml"}, index)
+
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,232 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Level represents the severity of a log entry
+type Level int
+
+const (
+	//DebugLevel entries describe details useful while looking for a problem
+	DebugLevel Level = iota
+	//InfoLevel entries describe the normal behaviour of the application
+	InfoLevel
+	//WarnLevel entries describe unexpected situations the application recovers from
+	WarnLevel
+	//ErrorLevel entries describe operations that failed
+	ErrorLevel
+)
+
+var levelNames = map[Level]string{
+	DebugLevel: "debug",
+	InfoLevel:  "info",
+	WarnLevel:  "warn",
+	ErrorLevel: "error",
+}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+//ParseLevel returns the Level with the given name: debug, info, warn or error
+func ParseLevel(name string) (Level, error) {
+	for level, levelName := range levelNames {
+		if strings.ToLower(name) == levelName {
+			return level, nil
+		}
+	}
+	return InfoLevel, fmt.Errorf("Unknown log level %q", name)
+}
+
+//Format represents how log entries are written
+type Format string
+
+const (
+	//TextFormat writes entries as key=value pairs
+	TextFormat Format = "text"
+	//JSONFormat writes entries as one JSON object per line
+	JSONFormat Format = "json"
+)
+
+const redacted = "[REDACTED]"
+
+//Field represents a key and value added to a log entry
+type Field struct {
+	Key   string
+	Value interface{}
+	pii   bool
+}
+
+//F returns a Field
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+//PII returns a Field with personally identifiable information, like participant names or
+//coordinates. Its value is redacted unless the Logger is built to keep PII
+func PII(key string, value interface{}) Field {
+	return Field{Key: key, Value: value, pii: true}
+}
+
+//Err returns a Field with an error
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+//Logger writes structured and leveled log entries
+type Logger interface {
+	Debug(message string, fields ...Field)
+	Info(message string, fields ...Field)
+	Warn(message string, fields ...Field)
+	Error(message string, fields ...Field)
+	With(fields ...Field) Logger
+	WithContext(ctx context.Context) Logger
+}
+
+//Options represents how a Logger writes its entries
+type Options struct {
+	Level  Level
+	Format Format
+	//KeepPII writes PII fields instead of redacting them
+	KeepPII bool
+}
+
+type output struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+type logger struct {
+	output  *output
+	options Options
+	fields  []Field
+	now     func() time.Time
+}
+
+func (l *logger) Debug(message string, fields ...Field) {
+	l.write(DebugLevel, message, fields)
+}
+
+func (l *logger) Info(message string, fields ...Field) {
+	l.write(InfoLevel, message, fields)
+}
+
+func (l *logger) Warn(message string, fields ...Field) {
+	l.write(WarnLevel, message, fields)
+}
+
+func (l *logger) Error(message string, fields ...Field) {
+	l.write(ErrorLevel, message, fields)
+}
+
+func (l *logger) With(fields ...Field) Logger {
+	withFields := make([]Field, 0, len(l.fields)+len(fields))
+	withFields = append(withFields, l.fields...)
+	withFields = append(withFields, fields...)
+	return &logger{
+		output:  l.output,
+		options: l.options,
+		fields:  withFields,
+		now:     l.now,
+	}
+}
+
+//WithContext returns a Logger that adds the request ID stored in the context to every entry
+func (l *logger) WithContext(ctx context.Context) Logger {
+	requestID := RequestID(ctx)
+	if requestID == "" {
+		return l
+	}
+	return l.With(F("request_id", requestID))
+}
+
+func (l *logger) fieldValue(field Field) interface{} {
+	if field.pii && !l.options.KeepPII {
+		return redacted
+	}
+	if err, ok := field.Value.(error); ok {
+		return err.Error()
+	}
+	return field.Value
+}
+
+func (l *logger) write(level Level, message string, fields []Field) {
+	if level < l.options.Level {
+		return
+	}
+	all := append(append([]Field{}, l.fields...), fields...)
+
+	var line []byte
+	if l.options.Format == JSONFormat {
+		entry := map[string]interface{}{
+			"time":    l.now().UTC().Format(time.RFC3339Nano),
+			"level":   level.String(),
+			"message": message,
+		}
+		for _, field := range all {
+			entry[field.Key] = l.fieldValue(field)
+		}
+		encoded, err := json.Marshal(entry)
+		if err != nil {
+			encoded = []byte(fmt.Sprintf(`{"level":"error","message":"Can't encode log entry: %s"}`, err))
+		}
+		line = append(encoded, '\n')
+	} else {
+		text := strings.Builder{}
+		fmt.Fprintf(&text, "%s %s %q", l.now().UTC().Format(time.RFC3339), strings.ToUpper(level.String()), message)
+		sorted := append([]Field{}, all...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+		for _, field := range sorted {
+			fmt.Fprintf(&text, " %s=%v", field.Key, formatTextValue(l.fieldValue(field)))
+		}
+		text.WriteString("\n")
+		line = []byte(text.String())
+	}
+
+	l.output.mu.Lock()
+	defer l.output.mu.Unlock()
+	l.output.writer.Write(line)
+}
+
+func formatTextValue(value interface{}) interface{} {
+	if text, ok := value.(string); ok && strings.ContainsAny(text, " =\"") {
+		return fmt.Sprintf("%q", text)
+	}
+	return value
+}
+
+//New returns a Logger that writes entries to w
+func New(w io.Writer, options Options) Logger {
+	return &logger{
+		output:  &output{writer: w},
+		options: options,
+		now:     time.Now,
+	}
+}
+
+//Default returns a Logger that writes text entries from info level to the standard error, redacting PII
+func Default() Logger {
+	return New(os.Stderr, Options{Level: InfoLevel, Format: TextFormat})
+}
+
+type requestIDKey struct{}
+
+//WithRequestID returns a context that carries the ID of the request being served
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+//RequestID returns the ID of the request stored in the context, or an empty string
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,88 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLogger(output *bytes.Buffer, options Options) Logger {
+	l := New(output, options).(*logger)
+	l.now = func() time.Time { return time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC) }
+	return l
+}
+
+func TestLogger(t *testing.T) {
+	t.Run("Given a logger with warn level, When entries are written, Then must skip the lower levels", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		logger := newTestLogger(output, Options{Level: WarnLevel})
+
+		logger.Debug("debug")
+		logger.Info("info")
+		logger.Warn("warn")
+		logger.Error("error")
+
+		assert.NotContains(t, output.String(), "DEBUG")
+		assert.NotContains(t, output.String(), "INFO")
+		assert.Contains(t, output.String(), `WARN "warn"`)
+		assert.Contains(t, output.String(), `ERROR "error"`)
+	})
+	t.Run("Given a text logger, When an entry is written, Then must write its fields sorted by key", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		logger := newTestLogger(output, Options{Format: TextFormat})
+
+		logger.With(F("route", "/matching/")).Info("Request served", F("status", 200), Err(errors.New("broken pipe")))
+
+		assert.Equal(t, "2020-01-02T03:04:05Z INFO \"Request served\" error=\"broken pipe\" route=/matching/ status=200\n", output.String())
+	})
+	t.Run("Given a JSON logger and a context with a request ID, When an entry is written, Then must write one JSON object with the request ID", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		logger := newTestLogger(output, Options{Format: JSONFormat})
+		ctx := WithRequestID(context.Background(), "request-1")
+
+		logger.WithContext(ctx).Info("Participants matched", F("participants", 2))
+
+		entry := map[string]interface{}{}
+		assert.Nil(t, json.Unmarshal(output.Bytes(), &entry))
+		assert.Equal(t, "info", entry["level"])
+		assert.Equal(t, "Participants matched", entry["message"])
+		assert.Equal(t, "request-1", entry["request_id"])
+		assert.Equal(t, 2.0, entry["participants"])
+	})
+	t.Run("Given PII fields, When they are logged by default, Then must be redacted", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		logger := newTestLogger(output, Options{Format: JSONFormat})
+
+		logger.Info("Participant scored", PII("name", "Jefferson"))
+
+		assert.Contains(t, output.String(), `"name":"[REDACTED]"`)
+		assert.NotContains(t, output.String(), "Jefferson")
+	})
+	t.Run("Given PII fields, When they are logged by a logger that keeps PII, Then must be written", func(t *testing.T) {
+		output := &bytes.Buffer{}
+		logger := newTestLogger(output, Options{Format: JSONFormat, KeepPII: true})
+
+		logger.Info("Participant scored", PII("name", "Jefferson"))
+
+		assert.Contains(t, output.String(), `"name":"Jefferson"`)
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Run("Given a level name, When it's parsed, Then must return the level", func(t *testing.T) {
+		level, err := ParseLevel("WARN")
+
+		assert.Nil(t, err)
+		assert.Equal(t, WarnLevel, level)
+	})
+	t.Run("Given an unknown level name, When it's parsed, Then must return an error", func(t *testing.T) {
+		_, err := ParseLevel("verbose")
+
+		assert.NotNil(t, err)
+	})
+}
@@ -1,23 +1,36 @@
 package matching
 
 import (
+	"context"
 	"errors"
-	"log"
+	"math"
 	"sort"
 	"sync"
+	"time"
+
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
 )
 
-const maxDistance = 100.00
+//DefaultMaxDistance is the radius in km around project cities where participants are looked for
+const DefaultMaxDistance = 100.00
 
 //ErrCantGetParticipantsNow is retrived when repository returns an error
 var ErrCantGetParticipantsNow = errors.New("Can't get participants now")
 
 //MatchingParticipant represents a Participant that match with a Project
 type MatchingParticipant struct {
-	Name       string  `json:"name"`
-	Distance   float64 `json:"distance"`
-	Score      float64 `json:"score"`
-	LocationID string  `json:"location_id"`
+	ID         string         `json:"id"`
+	Name       string         `json:"name"`
+	Distance   float64        `json:"distance"`
+	Score      float64        `json:"score"`
+	Breakdown  ScoreBreakdown `json:"breakdown"`
+	LocationID string         `json:"location_id"`
+	City       string         `json:"city"`
+	Seniority  Seniority      `json:"seniority,omitempty"`
+	//Variant is the name of the experiment variant that scored the participant
+	Variant string `json:"variant,omitempty"`
+	//Fatigue is the penalty subtracted from the score for the recent participations of the participant
+	Fatigue float64 `json:"fatigue,omitempty"`
 }
 
 type byScore []MatchingParticipant
@@ -27,6 +40,9 @@ func (s byScore) Len() int {
 }
 
 func (s byScore) Less(i, j int) bool {
+	if s[i].Score == s[j].Score {
+		return s[i].ID < s[j].ID
+	}
 	return s[j].Score < s[i].Score
 }
 
@@ -39,20 +55,144 @@ type DistanceParticipant struct {
 	Participant Participant
 	Distance    float64
 	LocationID  string
+	City        string
 }
 
 //Action represents the action of get Participants that matches with a Project
 type Action interface {
-	GetMatchingParticipantsForProject(p Project) ([]MatchingParticipant, error)
+	GetMatchingParticipantsForProject(ctx context.Context, p Project) ([]MatchingParticipant, error)
+	GetMatchingParticipantsPageForProject(ctx context.Context, p Project, page PageRequest) (ParticipantsPage, error)
+	StreamMatchingParticipantsForProject(ctx context.Context, p Project, emit func(MatchingParticipant) error) error
 }
 
 type action struct {
 	Participants ParticipantRepository
 	Distance     DistanceService
 	Score        ScoreService
+	rankings     *rankingCache
+	experiment   *Experiment
+	allocation   *AllocationCosts
+	history      ParticipationHistory
+	maxDistance  float64
+	metrics      Metrics
+	logger       logging.Logger
+	now          func() time.Time
+}
+
+//ActionOption customizes an Action built by NewMatchingParticipantsAction
+type ActionOption func(a *action)
+
+//WithMaxDistance changes the radius in km around project cities where participants are looked for
+func WithMaxDistance(maxDistance float64) ActionOption {
+	return func(a *action) {
+		a.maxDistance = maxDistance
+	}
+}
+
+//WithLogger changes the Logger where the action writes its entries
+func WithLogger(logger logging.Logger) ActionOption {
+	return func(a *action) {
+		a.logger = logger
+	}
+}
+
+func (a *action) GetMatchingParticipantsForProject(ctx context.Context, project Project) ([]MatchingParticipant, error) {
+	ranked, err := a.rankParticipants(ctx, project)
+	return ranked.participants, err
 }
 
-func (a *action) GetMatchingParticipantsForProject(project Project) ([]MatchingParticipant, error) {
+func (a *action) GetMatchingParticipantsPageForProject(ctx context.Context, project Project, page PageRequest) (ParticipantsPage, error) {
+	variant := a.variant(ctx, project)
+	key := projectKey(project) + "/" + variant.Name
+	ranked, ok := a.rankings.get(key)
+	if !ok || page.Cursor == "" {
+		var err error
+		ranked, err = a.rankParticipants(ctx, project)
+		if err != nil {
+			return ParticipantsPage{}, err
+		}
+		a.rankings.put(key, ranked)
+	} else {
+		a.logger.WithContext(ctx).Debug("Ranking found in cache", logging.F("participants", len(ranked.participants)))
+	}
+
+	participantsPage, err := paginate(ranked.participants, page)
+	participantsPage.Excluded = ranked.excluded
+	participantsPage.Variant = variant.Name
+	participantsPage.UnmetQuotas = ranked.unmetQuotas
+	participantsPage.Allocations = ranked.allocations
+	return participantsPage, err
+}
+
+//variant returns the Variant the project is scored with, an unnamed one with the Score of the action
+//when there is no experiment
+func (a *action) variant(ctx context.Context, project Project) Variant {
+	if a.experiment == nil {
+		return Variant{Score: a.Score}
+	}
+	return a.experiment.Assign(ExperimentKey(ctx, project))
+}
+
+//StreamMatchingParticipantsForProject emits every matching participant as soon as it's scored, so they aren't sorted.
+//When the project has quotas, or in allocation mode, the participants are selected first, so they are emitted sorted.
+//When emit returns an error the remaining participants are discarded and the error is returned
+func (a *action) StreamMatchingParticipantsForProject(ctx context.Context, project Project, emit func(MatchingParticipant) error) error {
+	if project.hasQuotas() || a.allocation != nil {
+		ranked, err := a.rankParticipants(ctx, project)
+		if err != nil {
+			return err
+		}
+		for _, participant := range ranked.participants {
+			if err := emit(participant); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	_, err := a.scoreParticipants(ctx, project, func(participant MatchingParticipant, _ Participant) error {
+		return emit(participant)
+	})
+	return err
+}
+
+func (a *action) rankParticipants(ctx context.Context, project Project) (ranking, error) {
+	matchingParticipants := []MatchingParticipant{}
+	profiles := map[string]Participant{}
+
+	excluded, err := a.scoreParticipants(ctx, project, func(participant MatchingParticipant, profile Participant) error {
+		matchingParticipants = append(matchingParticipants, participant)
+		if project.hasQuotas() || a.allocation != nil {
+			profiles[participant.ID] = profile
+		}
+		return nil
+	})
+	if err != nil {
+		return ranking{participants: []MatchingParticipant{}}, err
+	}
+
+	ranked := ranking{excluded: excluded}
+	if a.allocation != nil {
+		matchingParticipants, ranked.excluded.Capacity, ranked.allocations = a.allocate(project, matchingParticipants, profiles)
+	}
+
+	sort.Sort(byScore(matchingParticipants))
+
+	if !project.hasQuotas() {
+		ranked.participants = matchingParticipants
+		return ranked, nil
+	}
+	ranked.participants, ranked.unmetQuotas = selectParticipants(project, matchingParticipants, profiles)
+	ranked.excluded.Quota = len(matchingParticipants) - len(ranked.participants)
+	if len(ranked.unmetQuotas) > 0 {
+		a.logger.WithContext(ctx).Warn("Project quotas not met", logging.F("quotas", len(ranked.unmetQuotas)))
+	}
+	return ranked, nil
+}
+
+//scoreParticipants emits every participant of the project that isn't filtered out by its exclusions, its
+//required criteria, its fatigue cooldown or its minScore, with its profile, and returns how many were filtered out
+func (a *action) scoreParticipants(ctx context.Context, project Project, emit func(MatchingParticipant, Participant) error) (Exclusions, error) {
+	logger := a.logger.WithContext(ctx)
 	wg := sync.WaitGroup{}
 	participantsChan := make(chan DistanceParticipant)
 	errChan := make(chan error, 1)
@@ -65,45 +205,113 @@ func (a *action) GetMatchingParticipantsForProject(project Project) ([]MatchingP
 
 	for _, city := range project.Cities {
 		wg.Add(1)
-		go a.getParticipantsPerCity(errChan, participantsChan, city, &wg)
+		go a.getParticipantsPerCity(logger, errChan, participantsChan, city, &wg)
 	}
 
-	matchingParticipants := []MatchingParticipant{}
+	var errEmit error
+	emitted := 0
+	variant := a.variant(ctx, project)
+	if a.experiment != nil {
+		a.metrics.ObserveVariant(variant.Name)
+	}
+	exclusions := newExclusionFilter(project)
+	score := variant.Score.ForProject(project)
+	excluded := Exclusions{}
+	now := a.now()
 
 	for distanceParticipant := range participantsChan {
-		matchingParticipants = append(matchingParticipants, MatchingParticipant{
+		if errEmit != nil {
+			continue
+		}
+		if reason := exclusions.reason(distanceParticipant.Participant); reason != notExcluded {
+			excluded.add(reason)
+			continue
+		}
+		if !variant.Score.MeetsRequirements(project, distanceParticipant.Participant) {
+			excluded.add(excludedByRequirements)
+			continue
+		}
+		penalty := 0.0
+		if project.Fatigue != nil && a.history != nil {
+			participations, err := a.history.GetByParticipantID(distanceParticipant.Participant.ID)
+			if err != nil {
+				logger.Error("Can't get participation history", logging.F("participant_id", distanceParticipant.Participant.ID), logging.Err(err))
+				errEmit = ErrCantGetParticipantsNow
+				continue
+			}
+			cooldown, fatigue := project.Fatigue.evaluate(participations, now)
+			if cooldown {
+				excluded.add(excludedByCooldown)
+				continue
+			}
+			penalty = fatigue
+		}
+		breakdown := score.GetMatchingScoreBreakdown(distanceParticipant.Participant)
+		total := math.Max(0, breakdown.Total()-penalty)
+		a.metrics.ObserveScore(total)
+		if total < project.MinScore {
+			excluded.add(excludedByMinScore)
+			continue
+		}
+		logger.Debug("Participant scored",
+			logging.F("participant_id", distanceParticipant.Participant.ID),
+			logging.PII("name", distanceParticipant.Participant.Name),
+			logging.PII("location", distanceParticipant.Participant.Location),
+			logging.F("distance", distanceParticipant.Distance),
+			logging.F("score", total))
+		emitted++
+		errEmit = emit(MatchingParticipant{
+			ID:         distanceParticipant.Participant.ID,
 			Name:       distanceParticipant.Participant.Name,
-			Score:      a.Score.GetMatchingScore(project, distanceParticipant.Participant),
+			Score:      total,
+			Fatigue:    breakdown.Total() - total,
+			Breakdown:  breakdown,
 			Distance:   distanceParticipant.Distance,
 			LocationID: distanceParticipant.LocationID,
-		})
+			City:       distanceParticipant.City,
+			Seniority:  distanceParticipant.Participant.seniority(),
+			Variant:    variant.Name,
+		}, distanceParticipant.Participant)
 	}
 
 	err := <-errChan
+	if err == ErrParticipantsLoading {
+		return Exclusions{}, err
+	}
 	if err != nil {
-		return []MatchingParticipant{}, ErrCantGetParticipantsNow
+		return Exclusions{}, ErrCantGetParticipantsNow
 	}
 
-	sort.Sort(byScore(matchingParticipants))
-
-	return matchingParticipants, nil
+	a.metrics.ObserveResults(emitted)
+	logger.Info("Participants matched",
+		logging.F("cities", len(project.Cities)),
+		logging.F("participants", emitted),
+		logging.F("excluded", excluded.Total()),
+		logging.F("variant", variant.Name))
+	return excluded, errEmit
 }
 
-func (a *action) getParticipantsPerCity(errors chan error, participants chan DistanceParticipant, city City, wg *sync.WaitGroup) {
+func (a *action) getParticipantsPerCity(logger logging.Logger, errors chan error, participants chan DistanceParticipant, city City, wg *sync.WaitGroup) {
 	defer wg.Done()
+	start := time.Now()
 	cityParticipants, err := a.Participants.GetByFormattedAddress(city.CityLocation.FormattedAddress)
+	a.metrics.ObserveCityLookup(city.CityLocation.FormattedAddress, time.Since(start))
 	if err != nil {
-		log.Println(err)
-		errors <- err
+		logger.Error("Can't get participants for city", logging.F("city", city.CityLocation.FormattedAddress), logging.Err(err))
+		select {
+		case errors <- err:
+		default:
+		}
 		return
 	}
 	for _, p := range cityParticipants {
 		distance := a.Distance.GetDistanceBetweenLocations(p.Location, city.CityLocation.Location)
-		if distance <= maxDistance {
+		if distance <= a.maxDistance {
 			participants <- DistanceParticipant{
 				Participant: p,
 				Distance:    distance,
 				LocationID:  city.CityLocation.ID,
+				City:        city.CityLocation.FormattedAddress,
 			}
 		}
 	}
@@ -111,10 +319,19 @@ func (a *action) getParticipantsPerCity(errors chan error, participants chan Dis
 }
 
 //NewMatchingParticipantsAction returns an Action to get the matching participants from a project
-func NewMatchingParticipantsAction(repository ParticipantRepository, distance DistanceService, score ScoreService) Action {
-	return &action{
+func NewMatchingParticipantsAction(repository ParticipantRepository, distance DistanceService, score ScoreService, options ...ActionOption) Action {
+	a := &action{
 		Participants: repository,
 		Distance:     distance,
 		Score:        score,
+		rankings:     newRankingCache(),
+		maxDistance:  DefaultMaxDistance,
+		metrics:      noopMetrics{},
+		logger:       logging.Default(),
+		now:          time.Now,
+	}
+	for _, option := range options {
+		option(a)
 	}
+	return a
 }
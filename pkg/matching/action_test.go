@@ -1,9 +1,13 @@
 package matching
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/carlos-rodrigo/matching-app/pkg/logging"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -17,6 +21,33 @@ func (m *mockParticipantRepostory) GetByFormattedAddress(address string) ([]Part
 	return args.Get(0).([]Participant), args.Error(1)
 }
 
+type fakeMetrics struct {
+	cityLookups []string
+	results     []int
+	scores      []float64
+	variants    []string
+}
+
+func (m *fakeMetrics) ObserveCityLookup(city string, duration time.Duration) {
+	m.cityLookups = append(m.cityLookups, city)
+}
+
+func (m *fakeMetrics) ObserveResults(count int) {
+	m.results = append(m.results, count)
+}
+
+func (m *fakeMetrics) ObserveScore(score float64) {
+	m.scores = append(m.scores, score)
+}
+
+func (m *fakeMetrics) ObserveVariant(variant string) {
+	m.variants = append(m.variants, variant)
+}
+
+func (m *fakeMetrics) ObserveFeedback(variant string, accepted bool) {
+	m.variants = append(m.variants, variant)
+}
+
 func TestMatchingProjectWithParticipants(t *testing.T) {
 	distanceService := NewDistanceService()
 	scoreService := NewScoreService()
@@ -95,6 +126,7 @@ func TestMatchingProjectWithParticipants(t *testing.T) {
 
 	newYorkPaticipantsWithLessThan100KmDistance := []Participant{
 		Participant{
+			ID:               "jefferson",
 			Name:             "Jefferson",
 			Gender:           "male",
 			FormattedAddress: "New York, NY, USA",
@@ -105,6 +137,7 @@ func TestMatchingProjectWithParticipants(t *testing.T) {
 			JobTitle: "Software Engineer",
 		},
 		Participant{
+			ID:               "jillian",
 			Name:             "Jillian",
 			Gender:           "famele",
 			FormattedAddress: "New York, NY, USA",
@@ -117,6 +150,7 @@ func TestMatchingProjectWithParticipants(t *testing.T) {
 	}
 	phillyParticipantsWithLessThan100KmDistance := []Participant{
 		Participant{
+			ID:               "matthew",
 			Name:             "Matthew",
 			JobTitle:         "Senior Software Engineer",
 			FormattedAddress: "Philadelphia, PA, USA",
@@ -150,7 +184,7 @@ func TestMatchingProjectWithParticipants(t *testing.T) {
 		repository.On("GetByFormattedAddress", city).Return([]Participant{}, nil)
 		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
 
-		participants, _ := action.GetMatchingParticipantsForProject(projectWithOneCity)
+		participants, _ := action.GetMatchingParticipantsForProject(context.Background(), projectWithOneCity)
 
 		assert.Equal(t, 0, len(participants))
 	})
@@ -159,7 +193,7 @@ func TestMatchingProjectWithParticipants(t *testing.T) {
 		repository.On("GetByFormattedAddress", city).Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
 		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
 
-		participants, _ := action.GetMatchingParticipantsForProject(projectWithOneCity)
+		participants, _ := action.GetMatchingParticipantsForProject(context.Background(), projectWithOneCity)
 
 		assert.NotEqual(t, 0, len(participants))
 		assert.Equal(t, 2, len(participants))
@@ -175,7 +209,7 @@ func TestMatchingProjectWithParticipants(t *testing.T) {
 		repository.On("GetByFormattedAddress", city).Return([]Participant{}, errors.New("Can't access to storage now"))
 		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
 
-		_, err := action.GetMatchingParticipantsForProject(projectWithOneCity)
+		_, err := action.GetMatchingParticipantsForProject(context.Background(), projectWithOneCity)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, "Can't get participants now", err.Error())
@@ -187,7 +221,7 @@ func TestMatchingProjectWithParticipants(t *testing.T) {
 		repository.On("GetByFormattedAddress", "Philadelphia, PA, USA").Return(phillyParticipantsWithLessThan100KmDistance, nil)
 		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
 
-		participants, err := action.GetMatchingParticipantsForProject(projectWithTwoCities)
+		participants, err := action.GetMatchingParticipantsForProject(context.Background(), projectWithTwoCities)
 
 		assert.Nil(t, err)
 		assert.Equal(t, 3, len(participants))
@@ -201,7 +235,7 @@ func TestMatchingProjectWithParticipants(t *testing.T) {
 		repository.On("GetByFormattedAddress", "Philadelphia, PA, USA").Return(phillyParticipantsWithLessThan100KmDistance, nil)
 		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
 
-		participants, err := action.GetMatchingParticipantsForProject(projectWithTwoCities)
+		participants, err := action.GetMatchingParticipantsForProject(context.Background(), projectWithTwoCities)
 
 		assert.Nil(t, err)
 		assert.Equal(t, 2, len(participants))
@@ -216,7 +250,7 @@ func TestMatchingProjectWithParticipants(t *testing.T) {
 
 		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
 
-		participants, err := action.GetMatchingParticipantsForProject(projectWithTwoCities)
+		participants, err := action.GetMatchingParticipantsForProject(context.Background(), projectWithTwoCities)
 
 		assert.Nil(t, err)
 		assert.Equal(t, 3, len(participants))
@@ -224,4 +258,270 @@ func TestMatchingProjectWithParticipants(t *testing.T) {
 		assert.True(t, participants[1].Score >= participants[2].Score, "%+v p1 %+v p2", participants[1], participants[2])
 		repository.AssertExpectations(t)
 	})
+
+	t.Run("Given a Project, When participants are requested by pages, Then pages must follow the ranking without repeating participants", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", "New York, NY, USA").Return(newYorkPaticipantsWithLessThan100KmDistance, nil).Twice()
+		repository.On("GetByFormattedAddress", "Philadelphia, PA, USA").Return(phillyParticipantsWithLessThan100KmDistance, nil).Twice()
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
+
+		ranked, _ := action.GetMatchingParticipantsForProject(context.Background(), projectWithTwoCities)
+		first, err := action.GetMatchingParticipantsPageForProject(context.Background(), projectWithTwoCities, PageRequest{Limit: 2})
+		second, errSecond := action.GetMatchingParticipantsPageForProject(context.Background(), projectWithTwoCities, PageRequest{Limit: 2, Cursor: first.NextCursor})
+
+		assert.Nil(t, err)
+		assert.Nil(t, errSecond)
+		assert.Equal(t, 3, first.Total)
+		assert.Equal(t, ranked[:2], first.Participants)
+		assert.Equal(t, ranked[2:], second.Participants)
+		assert.Equal(t, "", second.NextCursor)
+	})
+	t.Run("Given a Project, When participants are streamed, Then every matching participant must be emitted with its score", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", "New York, NY, USA").Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		repository.On("GetByFormattedAddress", "Philadelphia, PA, USA").Return(phillyParticipantsWithLessThan100KmDistance, nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
+		streamed := []MatchingParticipant{}
+
+		err := action.StreamMatchingParticipantsForProject(context.Background(), projectWithTwoCities, func(participant MatchingParticipant) error {
+			streamed = append(streamed, participant)
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(streamed))
+		for _, participant := range streamed {
+			assert.Equal(t, participant.Breakdown.Total(), participant.Score)
+		}
+		repository.AssertExpectations(t)
+	})
+	t.Run("Given a Project, When emitting a streamed participant fails, Then must stop emitting and return the error", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", "New York, NY, USA").Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		repository.On("GetByFormattedAddress", "Philadelphia, PA, USA").Return(phillyParticipantsWithLessThan100KmDistance, nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
+		errClosedStream := errors.New("stream closed")
+		emitted := 0
+
+		err := action.StreamMatchingParticipantsForProject(context.Background(), projectWithTwoCities, func(participant MatchingParticipant) error {
+			emitted++
+			return errClosedStream
+		})
+
+		assert.Equal(t, errClosedStream, err)
+		assert.Equal(t, 1, emitted)
+	})
+	t.Run("Given an Action with a shorter max distance, When look for participants for the project, Then participants must be located inside that distance", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", city).Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService, WithMaxDistance(1))
+
+		participants, err := action.GetMatchingParticipantsForProject(context.Background(), projectWithOneCity)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(participants))
+		assert.Equal(t, "Jefferson", participants[0].Name)
+	})
+	t.Run("Given an Action with metrics, When participants are found, Then must record city lookups, results and scores", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", city).Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		metrics := &fakeMetrics{}
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService, WithMetrics(metrics))
+
+		_, err := action.GetMatchingParticipantsForProject(context.Background(), projectWithOneCity)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{city}, metrics.cityLookups)
+		assert.Equal(t, []int{2}, metrics.results)
+		assert.Equal(t, 2, len(metrics.scores))
+	})
+	t.Run("Given an Action with a logger, When a request is served, Then entries must carry the request ID and redact participant names", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", city).Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		output := &bytes.Buffer{}
+		logger := logging.New(output, logging.Options{Level: logging.DebugLevel, Format: logging.JSONFormat})
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService, WithLogger(logger))
+		ctx := logging.WithRequestID(context.Background(), "request-1")
+
+		_, err := action.GetMatchingParticipantsForProject(ctx, projectWithOneCity)
+
+		assert.Nil(t, err)
+		assert.Contains(t, output.String(), `"request_id":"request-1"`)
+		assert.Contains(t, output.String(), `"name":"[REDACTED]"`)
+		assert.NotContains(t, output.String(), "Jefferson")
+	})
+	t.Run("Given a Project with a strict seniority range, When participants are found, Then participants out of the range must be filtered out", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", city).Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
+		project := projectWithOneCity
+		project.Seniority = &SeniorityRange{Min: SenioritySenior, Strict: true}
+
+		participants, err := action.GetMatchingParticipantsForProject(context.Background(), project)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(participants))
+		assert.Equal(t, "Jillian", participants[0].Name)
+		assert.Equal(t, SenioritySenior, participants[0].Seniority)
+	})
+	t.Run("Given a Project with exclusions, When a page of participants is found, Then excluded participants must be filtered out and counted", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", city).Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
+		project := projectWithOneCity
+		project.ExclusionKeywords = []string{"senior"}
+
+		page, err := action.GetMatchingParticipantsPageForProject(context.Background(), project, PageRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, page.Total)
+		assert.Equal(t, "Jefferson", page.Participants[0].Name)
+		assert.Equal(t, Exclusions{Keyword: 1}, page.Excluded)
+	})
+	t.Run("Given a Project with required criteria and a min score, When a page of participants is found, Then participants not meeting them must be filtered out and counted", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", city).Return(append(newYorkPaticipantsWithLessThan100KmDistance, Participant{
+			ID:               "tom",
+			Name:             "Tom",
+			FormattedAddress: "New York, NY, USA",
+			Location:         Location{Latitude: 40.7127753, Longitude: -74.0059728},
+			JobTitle:         "Recruiter",
+		}), nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
+		project := projectWithOneCity
+		project.ProfessionalJobTitles = []string{"Software Engineer"}
+		project.Required = []Criterion{CriterionJobTitle}
+		project.Seniority = &SeniorityRange{Min: SenioritySenior}
+		project.MinScore = 0.8
+
+		page, err := action.GetMatchingParticipantsPageForProject(context.Background(), project, PageRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, page.Total)
+		assert.Equal(t, "Jillian", page.Participants[0].Name)
+		assert.Equal(t, Exclusions{Required: 1, MinScore: 1}, page.Excluded)
+	})
+	t.Run("Given a Project with a query, When a page of participants is found, Then participants not meeting it must be filtered out and counted", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", city).Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
+		project := projectWithOneCity
+		project.Query = `title:"software engineer" AND NOT seniority:senior`
+
+		page, err := action.GetMatchingParticipantsPageForProject(context.Background(), project, PageRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, page.Total)
+		assert.Equal(t, "Jefferson", page.Participants[0].Name)
+		assert.Equal(t, Exclusions{Query: 1}, page.Excluded)
+	})
+	t.Run("Given a Project with quotas, When a page of participants is found, Then must select the participants meeting them and report the unmet quotas", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", "New York, NY, USA").Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		repository.On("GetByFormattedAddress", "Philadelphia, PA, USA").Return(phillyParticipantsWithLessThan100KmDistance, nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
+		project := projectWithTwoCities
+		project.Seniority = &SeniorityRange{Min: SenioritySenior}
+		project.Quotas = []Quota{
+			{Field: QuotaCity, Value: "Philadelphia", Min: 1},
+			{Field: QuotaGender, Value: "male", Min: 2},
+		}
+		project.SelectionSize = 2
+
+		page, err := action.GetMatchingParticipantsPageForProject(context.Background(), project, PageRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, page.Total)
+		assert.Equal(t, "Matthew", page.Participants[0].Name)
+		assert.Equal(t, "Jefferson", page.Participants[1].Name)
+		assert.Equal(t, Exclusions{Quota: 1}, page.Excluded)
+		assert.Equal(t, []UnmetQuota{{Quota: project.Quotas[1], Selected: 1}}, page.UnmetQuotas)
+	})
+	t.Run("Given a Project with quotas, When participants are streamed, Then only the selected participants must be emitted sorted by score", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", city).Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService)
+		project := projectWithOneCity
+		project.Quotas = []Quota{{Field: QuotaGender, Value: "male", Max: 1}}
+		project.SelectionSize = 1
+		emitted := []string{}
+
+		err := action.StreamMatchingParticipantsForProject(context.Background(), project, func(participant MatchingParticipant) error {
+			emitted = append(emitted, participant.Name)
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(emitted))
+	})
+	t.Run("Given an Action in allocation mode and cities with capacities, When a page of participants is found, Then every participant must be assigned to one city in range within its capacity", func(t *testing.T) {
+		manhattan := City{CityLocation: CityLocation{ID: "manhattan", FormattedAddress: "New York, NY, USA", Location: Location{Latitude: 40.7127753, Longitude: -74.0059728}}, Capacity: 1}
+		brooklyn := City{CityLocation: CityLocation{ID: "brooklyn", FormattedAddress: "Brooklyn, NY, USA", Location: Location{Latitude: 40.6781784, Longitude: -73.9441579}}, Capacity: 1}
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", "New York, NY, USA").Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		repository.On("GetByFormattedAddress", "Brooklyn, NY, USA").Return([]Participant{}, nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService, WithAllocation(DefaultAllocationCosts))
+		project := Project{Cities: []City{manhattan, brooklyn}}
+
+		page, err := action.GetMatchingParticipantsPageForProject(context.Background(), project, PageRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, page.Total)
+		cities := map[string]string{}
+		for _, participant := range page.Participants {
+			cities[participant.Name] = participant.LocationID
+		}
+		assert.Equal(t, map[string]string{"Jefferson": "manhattan", "Jillian": "brooklyn"}, cities)
+		assert.Equal(t, []CityAllocation{
+			{City: "New York, NY, USA", Capacity: 1, Assigned: 1},
+			{City: "Brooklyn, NY, USA", Capacity: 1, Assigned: 1},
+		}, page.Allocations)
+		assert.Equal(t, Exclusions{}, page.Excluded)
+	})
+	t.Run("Given an Action in allocation mode and full cities, When a page of participants is found, Then participants that don't fit must be left out and counted", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", city).Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService, WithAllocation(DefaultAllocationCosts))
+		project := projectWithOneCity
+		project.Cities = []City{{CityLocation: projectWithOneCity.Cities[0].CityLocation, Capacity: 1}}
+		project.ProfessionalJobTitles = []string{"Software Engineer"}
+		project.Seniority = &SeniorityRange{Min: SenioritySenior}
+
+		page, err := action.GetMatchingParticipantsPageForProject(context.Background(), project, PageRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, page.Total)
+		assert.Equal(t, "Jillian", page.Participants[0].Name)
+		assert.Equal(t, Exclusions{Capacity: 1}, page.Excluded)
+	})
+	t.Run("Given a Project with a fatigue rule and an Action with participation history, When a page of participants is found, Then participants in cooldown must be filtered out and frequent ones penalized", func(t *testing.T) {
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", "New York, NY, USA").Return(newYorkPaticipantsWithLessThan100KmDistance, nil)
+		repository.On("GetByFormattedAddress", "Philadelphia, PA, USA").Return(phillyParticipantsWithLessThan100KmDistance, nil)
+		now := time.Now()
+		history := participationsByParticipant{
+			"jefferson": {{ParticipantID: "jefferson", ProjectID: "p1", Date: now.AddDate(0, 0, -3), Outcome: OutcomeCompleted}},
+			"jillian": {
+				{ParticipantID: "jillian", ProjectID: "p2", Date: now.AddDate(0, 0, -20), Outcome: OutcomeCompleted},
+				{ParticipantID: "jillian", ProjectID: "p3", Date: now.AddDate(0, 0, -40), Outcome: OutcomeNoShow},
+			},
+			"matthew": {{ParticipantID: "matthew", ProjectID: "p4", Date: now.AddDate(0, 0, -5), Outcome: OutcomeDeclined}},
+		}
+		action := NewMatchingParticipantsAction(repository, distanceService, scoreService, WithParticipationHistory(history))
+		project := projectWithTwoCities
+		project.Fatigue = &FatigueRule{CooldownDays: 7, Penalty: 0.1}
+
+		page, err := action.GetMatchingParticipantsPageForProject(context.Background(), project, PageRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, page.Total)
+		assert.Equal(t, Exclusions{Cooldown: 1}, page.Excluded)
+		participants := map[string]MatchingParticipant{}
+		for _, participant := range page.Participants {
+			participants[participant.ID] = participant
+		}
+		assert.InDelta(t, 0.2, participants["jillian"].Fatigue, 0.000001)
+		assert.InDelta(t, participants["jillian"].Breakdown.Total()-0.2, participants["jillian"].Score, 0.000001)
+		assert.Equal(t, 0.0, participants["matthew"].Fatigue)
+	})
 }
@@ -0,0 +1,89 @@
+package matching
+
+//AllocationCosts weights what assigning a participant to a city costs, so the cheapest assignment keeps
+//the best participants when capacities are short, and assigns them to their nearest cities
+type AllocationCosts struct {
+	//Score weights the score the participant misses, 1 minus its score
+	Score float64
+	//Distance weights the distance to the city, relative to the max distance of the Action
+	Distance float64
+}
+
+//DefaultAllocationCosts weights the score and the distance the same
+var DefaultAllocationCosts = AllocationCosts{Score: 1, Distance: 1}
+
+func (c AllocationCosts) cost(score float64, distance float64) float64 {
+	return c.Score*(1-score) + c.Distance*distance
+}
+
+//CityAllocation represents how many participants were assigned to a project city in allocation mode
+type CityAllocation struct {
+	City string `json:"city"`
+	//Capacity is the capacity of the city, 0 when it's unlimited
+	Capacity int `json:"capacity"`
+	Assigned int `json:"assigned"`
+}
+
+//WithAllocation assigns every participant to one of the project cities in range, instead of the city it was
+//found by, filling the city capacities with the assignment of the lowest cost. Participants that don't fit
+//in the capacities are left out
+func WithAllocation(costs AllocationCosts) ActionOption {
+	return func(a *action) {
+		a.allocation = &costs
+	}
+}
+
+//allocate returns the participants assigned to the project cities by the allocation costs, how many of them
+//didn't fit in the city capacities, and the participants assigned to every city
+func (a *action) allocate(project Project, scored []MatchingParticipant, profiles map[string]Participant) ([]MatchingParticipant, int, []CityAllocation) {
+	//participants found by several cities are scored once per city, but assigned once
+	participants := []MatchingParticipant{}
+	found := map[string]bool{}
+	for _, participant := range scored {
+		if !found[participant.ID] {
+			found[participant.ID] = true
+			participants = append(participants, participant)
+		}
+	}
+
+	capacities := make([]int, len(project.Cities))
+	allocations := make([]CityAllocation, len(project.Cities))
+	for i, city := range project.Cities {
+		capacities[i] = city.Capacity
+		if city.Capacity == 0 {
+			capacities[i] = len(participants)
+		}
+		allocations[i] = CityAllocation{City: city.CityLocation.FormattedAddress, Capacity: city.Capacity}
+	}
+
+	edges := []AssignmentEdge{}
+	distances := map[[2]int]float64{}
+	for i, participant := range participants {
+		for j, city := range project.Cities {
+			distance := a.Distance.GetDistanceBetweenLocations(profiles[participant.ID].Location, city.CityLocation.Location)
+			if distance > a.maxDistance {
+				continue
+			}
+			distances[[2]int{i, j}] = distance
+			relative := 0.0
+			if a.maxDistance > 0 {
+				relative = distance / a.maxDistance
+			}
+			edges = append(edges, AssignmentEdge{Participant: i, City: j, Cost: a.allocation.cost(participant.Score, relative)})
+		}
+	}
+
+	assigned := []MatchingParticipant{}
+	for i, city := range SolveAssignment(len(participants), capacities, edges) {
+		if city == Unassigned {
+			continue
+		}
+		participant := participants[i]
+		participant.City = project.Cities[city].CityLocation.FormattedAddress
+		participant.LocationID = project.Cities[city].CityLocation.ID
+		participant.Distance = distances[[2]int{i, city}]
+		allocations[city].Assigned++
+		assigned = append(assigned, participant)
+	}
+	return assigned, len(participants) - len(assigned), allocations
+}
@@ -0,0 +1,158 @@
+package matching
+
+import (
+	"container/heap"
+	"math"
+)
+
+//AssignmentEdge is a city a participant can be assigned to, with the cost of assigning it there
+type AssignmentEdge struct {
+	Participant int
+	City        int
+	Cost        float64
+}
+
+//Unassigned is the city of the participants SolveAssignment can't assign
+const Unassigned = -1
+
+//SolveAssignment assigns participants to cities through the given edges, with at most capacities[city] participants
+//per city and every participant in one city at most. It assigns as many participants as the capacities allow, with
+//the lowest total cost among those assignments, and returns the city of every participant or Unassigned. Costs
+//must not be negative.
+//It's solved as a min-cost flow, augmenting the cheapest path from a participant to a city with free capacity
+//at a time, so it takes O(participants * edges * log(participants + cities))
+func SolveAssignment(participants int, capacities []int, edges []AssignmentEdge) []int {
+	//nodes are the source, every participant, every city and the sink
+	source := 0
+	sink := participants + len(capacities) + 1
+	graph := newFlowGraph(sink + 1)
+	for participant := 0; participant < participants; participant++ {
+		graph.add(source, 1+participant, 1, 0)
+	}
+	for city, capacity := range capacities {
+		graph.add(1+participants+city, sink, capacity, 0)
+	}
+	for _, edge := range edges {
+		graph.add(1+edge.Participant, 1+participants+edge.City, 1, edge.Cost)
+	}
+
+	graph.minCostFlow(source, sink)
+
+	assigned := make([]int, participants)
+	for participant := range assigned {
+		assigned[participant] = Unassigned
+		for _, edge := range graph.edges[1+participant] {
+			if edge.to > participants && edge.to < sink && edge.capacity == 0 {
+				assigned[participant] = edge.to - participants - 1
+			}
+		}
+	}
+	return assigned
+}
+
+type flowEdge struct {
+	to       int
+	reverse  int
+	capacity int
+	cost     float64
+}
+
+//flowGraph is a residual graph, every edge has a reverse one with no capacity and the opposite cost
+type flowGraph struct {
+	edges [][]flowEdge
+}
+
+func newFlowGraph(nodes int) *flowGraph {
+	return &flowGraph{edges: make([][]flowEdge, nodes)}
+}
+
+func (g *flowGraph) add(from, to, capacity int, cost float64) {
+	g.edges[from] = append(g.edges[from], flowEdge{to: to, reverse: len(g.edges[to]), capacity: capacity, cost: cost})
+	g.edges[to] = append(g.edges[to], flowEdge{to: from, reverse: len(g.edges[from]) - 1, capacity: 0, cost: -cost})
+}
+
+//minCostFlow sends as much flow as possible from source to sink along the cheapest paths. Paths are found
+//with Dijkstra over costs reduced by node potentials, which keeps them positive once reverse edges are used
+func (g *flowGraph) minCostFlow(source, sink int) {
+	nodes := len(g.edges)
+	potential := make([]float64, nodes)
+	distance := make([]float64, nodes)
+	previous := make([][2]int, nodes)
+	for {
+		for node := range distance {
+			distance[node] = math.Inf(1)
+		}
+		distance[source] = 0
+		queue := &nodeQueue{{node: source}}
+		for queue.Len() > 0 {
+			current := heap.Pop(queue).(queuedNode)
+			if current.distance > distance[current.node] {
+				continue
+			}
+			for i, edge := range g.edges[current.node] {
+				if edge.capacity == 0 {
+					continue
+				}
+				//rounding can make reduced costs slightly negative
+				reduced := math.Max(0, edge.cost+potential[current.node]-potential[edge.to])
+				if next := distance[current.node] + reduced; next < distance[edge.to] {
+					distance[edge.to] = next
+					previous[edge.to] = [2]int{current.node, i}
+					heap.Push(queue, queuedNode{node: edge.to, distance: next})
+				}
+			}
+		}
+		if math.IsInf(distance[sink], 1) {
+			return
+		}
+		for node := range potential {
+			if !math.IsInf(distance[node], 1) {
+				potential[node] += distance[node]
+			}
+		}
+
+		flow := math.MaxInt32
+		for node := sink; node != source; node = previous[node][0] {
+			edge := g.edges[previous[node][0]][previous[node][1]]
+			if edge.capacity < flow {
+				flow = edge.capacity
+			}
+		}
+		for node := sink; node != source; node = previous[node][0] {
+			edge := &g.edges[previous[node][0]][previous[node][1]]
+			edge.capacity -= flow
+			g.edges[node][edge.reverse].capacity += flow
+		}
+	}
+}
+
+type queuedNode struct {
+	node     int
+	distance float64
+}
+
+//nodeQueue is a min heap of nodes by their distance
+type nodeQueue []queuedNode
+
+func (q nodeQueue) Len() int {
+	return len(q)
+}
+
+func (q nodeQueue) Less(i, j int) bool {
+	return q[i].distance < q[j].distance
+}
+
+func (q nodeQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *nodeQueue) Push(x interface{}) {
+	*q = append(*q, x.(queuedNode))
+}
+
+func (q *nodeQueue) Pop() interface{} {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return last
+}
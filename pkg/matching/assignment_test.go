@@ -0,0 +1,45 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveAssignment(t *testing.T) {
+	t.Run("Given two participants preferring the same full city, When they are assigned, Then the assignment must have the lowest total cost instead of serving the first one", func(t *testing.T) {
+		edges := []AssignmentEdge{
+			{Participant: 0, City: 0, Cost: 0.1},
+			{Participant: 0, City: 1, Cost: 0.2},
+			{Participant: 1, City: 0, Cost: 0.1},
+			{Participant: 1, City: 1, Cost: 0.9},
+		}
+
+		assigned := SolveAssignment(2, []int{1, 1}, edges)
+
+		assert.Equal(t, []int{1, 0}, assigned)
+	})
+	t.Run("Given more participants than capacity, When they are assigned, Then the cheapest ones must be assigned and the rest left unassigned", func(t *testing.T) {
+		edges := []AssignmentEdge{
+			{Participant: 0, City: 0, Cost: 0.5},
+			{Participant: 1, City: 0, Cost: 0.1},
+			{Participant: 2, City: 0, Cost: 0.3},
+		}
+
+		assigned := SolveAssignment(3, []int{2}, edges)
+
+		assert.Equal(t, []int{Unassigned, 0, 0}, assigned)
+	})
+	t.Run("Given a participant that can only go to a full city, When they are assigned, Then must assign as many participants as possible", func(t *testing.T) {
+		edges := []AssignmentEdge{
+			{Participant: 0, City: 0, Cost: 0.1},
+			{Participant: 0, City: 1, Cost: 0.8},
+			{Participant: 1, City: 0, Cost: 0.7},
+			{Participant: 2, City: 2, Cost: 0},
+		}
+
+		assigned := SolveAssignment(4, []int{1, 1, 0}, edges)
+
+		assert.Equal(t, []int{1, 0, Unassigned, Unassigned}, assigned)
+	})
+}
@@ -0,0 +1,99 @@
+package matching
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Criterion represents a criteria of a Project that participants are matched by
+type Criterion string
+
+const (
+	CriterionIndustry  Criterion = "industry"
+	CriterionJobTitle  Criterion = "jobTitle"
+	CriterionSeniority Criterion = "seniority"
+	CriterionGender    Criterion = "gender"
+	CriterionEducation Criterion = "education"
+)
+
+//Criteria are every Criterion a Project can require
+var Criteria = []Criterion{CriterionIndustry, CriterionJobTitle, CriterionSeniority, CriterionGender, CriterionEducation}
+
+//anyGender are the values of Project genders that accept every gender
+var anyGender = map[string]bool{
+	"":    true,
+	"n/a": true,
+	"na":  true,
+	"any": true,
+	"all": true,
+}
+
+//requires returns true when the criterion must be met by participants instead of adding to their score
+func (p Project) requires(criterion Criterion) bool {
+	for _, required := range p.Required {
+		if required == criterion {
+			return true
+		}
+	}
+	return false
+}
+
+//asks returns true when the project gives the values the criterion is matched with
+func (p Project) asks(criterion Criterion) bool {
+	switch criterion {
+	case CriterionIndustry:
+		return len(p.ProfessionalIndustry) > 0
+	case CriterionJobTitle:
+		return len(p.ProfessionalJobTitles) > 0
+	case CriterionSeniority:
+		return p.Seniority != nil
+	case CriterionGender:
+		return len(p.genders()) > 0
+	case CriterionEducation:
+		return len(p.Education) > 0
+	}
+	return false
+}
+
+//genders returns the lowercased genders of the comma separated Genders, none when any gender is accepted
+func (p Project) genders() []string {
+	genders := []string{}
+	for _, gender := range strings.Split(p.Genders, ",") {
+		gender = strings.ToLower(strings.TrimSpace(gender))
+		if anyGender[gender] {
+			continue
+		}
+		genders = append(genders, gender)
+	}
+	return genders
+}
+
+//validateRequired returns the problems of the required criteria of the project
+func (p Project) validateRequired() []string {
+	problems := []string{}
+	for _, required := range p.Required {
+		known := false
+		for _, criterion := range Criteria {
+			if required == criterion {
+				known = true
+			}
+		}
+		if !known {
+			problems = append(problems, fmt.Sprintf("required criterion %q is unknown", required))
+		} else if !p.asks(required) {
+			problems = append(problems, fmt.Sprintf("required criterion %q has no values", required))
+		}
+	}
+	return problems
+}
+
+//containsFold returns true when values has the value, ignoring case and surrounding spaces
+func containsFold(values []string, value string) bool {
+	value = strings.TrimSpace(value)
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package matching
+
+import "math"
+
+//RankingMetrics measure how well a ranking of participants puts the relevant ones first, from 0 to 1
+type RankingMetrics struct {
+	//Precision is the share of the first k participants that are relevant
+	Precision float64 `json:"precision"`
+	//Recall is the share of the relevant participants found in the first k
+	Recall float64 `json:"recall"`
+	//NDCG is the discounted cumulative gain of the first k participants, divided by the gain of the best ranking
+	NDCG float64 `json:"ndcg"`
+	//MRR is the reciprocal rank of the first relevant participant in the whole ranking
+	MRR float64 `json:"mrr"`
+}
+
+//Sub returns the difference of every metric with the other metrics
+func (m RankingMetrics) Sub(other RankingMetrics) RankingMetrics {
+	return RankingMetrics{
+		Precision: m.Precision - other.Precision,
+		Recall:    m.Recall - other.Recall,
+		NDCG:      m.NDCG - other.NDCG,
+		MRR:       m.MRR - other.MRR,
+	}
+}
+
+//EvaluateRanking returns the RankingMetrics at k of the ranked participant IDs, given the IDs of the relevant ones
+func EvaluateRanking(ranked []string, relevant []string, k int) RankingMetrics {
+	metrics := RankingMetrics{}
+	if k <= 0 || len(relevant) == 0 {
+		return metrics
+	}
+	isRelevant := map[string]bool{}
+	for _, id := range relevant {
+		isRelevant[id] = true
+	}
+
+	hits := 0
+	dcg := 0.0
+	for i, id := range ranked {
+		if !isRelevant[id] {
+			continue
+		}
+		if metrics.MRR == 0 {
+			metrics.MRR = 1 / float64(i+1)
+		}
+		if i < k {
+			hits++
+			dcg += 1 / math.Log2(float64(i+2))
+		}
+	}
+	idcg := 0.0
+	for i := 0; i < k && i < len(isRelevant); i++ {
+		idcg += 1 / math.Log2(float64(i+2))
+	}
+
+	metrics.Precision = float64(hits) / float64(k)
+	metrics.Recall = float64(hits) / float64(len(isRelevant))
+	metrics.NDCG = dcg / idcg
+	return metrics
+}
+
+//MeanRankingMetrics returns the mean of every metric
+func MeanRankingMetrics(metrics []RankingMetrics) RankingMetrics {
+	mean := RankingMetrics{}
+	if len(metrics) == 0 {
+		return mean
+	}
+	for _, m := range metrics {
+		mean.Precision += m.Precision
+		mean.Recall += m.Recall
+		mean.NDCG += m.NDCG
+		mean.MRR += m.MRR
+	}
+	count := float64(len(metrics))
+	return RankingMetrics{
+		Precision: mean.Precision / count,
+		Recall:    mean.Recall / count,
+		NDCG:      mean.NDCG / count,
+		MRR:       mean.MRR / count,
+	}
+}
@@ -0,0 +1,39 @@
+package matching
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateRanking(t *testing.T) {
+	t.Run("Given a ranking with relevant participants, When it's evaluated at k, Then must return its precision, recall, NDCG and MRR", func(t *testing.T) {
+		metrics := EvaluateRanking([]string{"a", "b", "c", "d", "e"}, []string{"b", "d", "z"}, 3)
+
+		assert.InDelta(t, 1.0/3, metrics.Precision, 0.0001)
+		assert.InDelta(t, 1.0/3, metrics.Recall, 0.0001)
+		idcg := 1 + 1/math.Log2(3) + 1/math.Log2(4)
+		assert.InDelta(t, (1/math.Log2(3))/idcg, metrics.NDCG, 0.0001)
+		assert.Equal(t, 0.5, metrics.MRR)
+	})
+	t.Run("Given the best ranking, When it's evaluated, Then NDCG must be 1", func(t *testing.T) {
+		metrics := EvaluateRanking([]string{"a", "b", "c"}, []string{"a", "b"}, 5)
+
+		assert.InDelta(t, 1.0, metrics.NDCG, 0.0001)
+		assert.Equal(t, 1.0, metrics.Recall)
+		assert.Equal(t, 0.4, metrics.Precision)
+		assert.Equal(t, 1.0, metrics.MRR)
+	})
+	t.Run("Given a relevant participant after k, When it's evaluated, Then must only count for MRR", func(t *testing.T) {
+		metrics := EvaluateRanking([]string{"a", "b", "c", "d"}, []string{"d"}, 2)
+
+		assert.Equal(t, RankingMetrics{MRR: 0.25}, metrics)
+	})
+	t.Run("Given the metrics of several projects, When they are averaged and compared, Then must return their mean and difference", func(t *testing.T) {
+		mean := MeanRankingMetrics([]RankingMetrics{{Precision: 1, NDCG: 0.5}, {Precision: 0.5, MRR: 1}})
+
+		assert.Equal(t, RankingMetrics{Precision: 0.75, NDCG: 0.25, MRR: 0.5}, mean)
+		assert.Equal(t, RankingMetrics{Precision: 0.25, NDCG: 0.25, MRR: -0.5}, mean.Sub(RankingMetrics{Precision: 0.5, MRR: 1}))
+	})
+}
@@ -0,0 +1,161 @@
+package matching
+
+import (
+	"strings"
+	"unicode"
+)
+
+//defaultIndustries resolves the industries excluded by projects
+var defaultIndustries = DefaultIndustryTaxonomy()
+
+//Exclusions represents how many participants were filtered out of the results of a project, by reason.
+//A participant is only counted by the first reason found, in the order of the fields
+type Exclusions struct {
+	Industry  int `json:"industry"`
+	JobTitle  int `json:"jobTitle"`
+	Keyword   int `json:"keyword"`
+	Seniority int `json:"seniority"`
+	//Query counts the participants that don't meet the project query
+	Query int `json:"query"`
+	//Required counts the participants that don't meet the criteria required by the project
+	Required int `json:"required"`
+	//Cooldown counts the participants that took part in a project within the cooldown days of the project fatigue rule
+	Cooldown int `json:"cooldown"`
+	//MinScore counts the participants scoring below the project minScore
+	MinScore int `json:"minScore"`
+	//Quota counts the participants left out of the selection by the project quotas or selectionSize
+	Quota int `json:"quota"`
+	//Capacity counts the participants that didn't fit in the capacities of the project cities in allocation mode
+	Capacity int `json:"capacity"`
+}
+
+//Total returns the amount of participants filtered out
+func (e Exclusions) Total() int {
+	return e.Industry + e.JobTitle + e.Keyword + e.Seniority + e.Query + e.Required + e.Cooldown + e.MinScore + e.Quota + e.Capacity
+}
+
+type exclusionReason int
+
+const (
+	notExcluded exclusionReason = iota
+	excludedByIndustry
+	excludedByJobTitle
+	excludedByKeyword
+	excludedBySeniority
+	excludedByQuery
+	excludedByRequirements
+	excludedByCooldown
+	excludedByMinScore
+)
+
+func (e *Exclusions) add(reason exclusionReason) {
+	switch reason {
+	case excludedByIndustry:
+		e.Industry++
+	case excludedByJobTitle:
+		e.JobTitle++
+	case excludedByKeyword:
+		e.Keyword++
+	case excludedBySeniority:
+		e.Seniority++
+	case excludedByQuery:
+		e.Query++
+	case excludedByRequirements:
+		e.Required++
+	case excludedByCooldown:
+		e.Cooldown++
+	case excludedByMinScore:
+		e.MinScore++
+	}
+}
+
+//exclusionFilter finds the participants a project filters out before they are scored
+type exclusionFilter struct {
+	industries []string
+	titles     []string
+	keywords   [][]string
+	seniority  *SeniorityRange
+	query      *Query
+	matcher    TitleMatcher
+	threshold  float64
+}
+
+func newExclusionFilter(project Project) exclusionFilter {
+	filter := exclusionFilter{
+		industries: project.ExcludedIndustries,
+		titles:     project.ExcludedJobTitles,
+		matcher:    NewTitleMatcher(),
+		threshold:  project.jobTitleThreshold(),
+	}
+	for _, keyword := range project.ExclusionKeywords {
+		if words := keywordWords(keyword); len(words) > 0 {
+			filter.keywords = append(filter.keywords, words)
+		}
+	}
+	if project.Seniority != nil && project.Seniority.Strict {
+		filter.seniority = project.Seniority
+	}
+	if strings.TrimSpace(project.Query) != "" {
+		//projects are validated before matching, so the query can be parsed
+		filter.query, _ = ParseQuery(project.Query)
+	}
+	return filter
+}
+
+//reason returns why the participant is filtered out, or notExcluded when it isn't
+func (f exclusionFilter) reason(participant Participant) exclusionReason {
+	for _, excluded := range f.industries {
+		for _, industry := range participant.Industry {
+			if defaultIndustries.Covers(excluded, industry) {
+				return excludedByIndustry
+			}
+		}
+	}
+	for _, excluded := range f.titles {
+		if f.matcher.Similarity(excluded, participant.JobTitle) >= f.threshold {
+			return excludedByJobTitle
+		}
+	}
+	if len(f.keywords) > 0 {
+		fields := append([]string{participant.JobTitle}, participant.Industry...)
+		for _, field := range fields {
+			words := keywordWords(field)
+			for _, keyword := range f.keywords {
+				if containsWords(words, keyword) {
+					return excludedByKeyword
+				}
+			}
+		}
+	}
+	if f.seniority != nil && !f.seniority.Contains(participant.seniority()) {
+		return excludedBySeniority
+	}
+	if f.query != nil && !f.query.Matches(participant) {
+		return excludedByQuery
+	}
+	return notExcluded
+}
+
+//keywordWords returns the lowercased words of a text, without punctuation
+func keywordWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+//containsWords returns true when words has every word of keyword one after the other
+func containsWords(words []string, keyword []string) bool {
+	for i := 0; i+len(keyword) <= len(words); i++ {
+		found := true
+		for j, word := range keyword {
+			if words[i+j] != word {
+				found = false
+				break
+			}
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExclusionFilter(t *testing.T) {
+	t.Run("Given a project with excluded industries, When participants are filtered, Then participants with those industries or industries of those groups must be excluded", func(t *testing.T) {
+		filter := newExclusionFilter(Project{ExcludedIndustries: []string{"market research", "Finance"}})
+
+		assert.Equal(t, excludedByIndustry, filter.reason(Participant{Industry: []string{"Retail", "Market Research"}}))
+		assert.Equal(t, excludedByIndustry, filter.reason(Participant{Industry: []string{"Investment Banking"}}))
+		assert.Equal(t, notExcluded, filter.reason(Participant{Industry: []string{"Computer Software"}}))
+	})
+	t.Run("Given a project with excluded job titles, When participants are filtered, Then participants whose job title matches them must be excluded", func(t *testing.T) {
+		filter := newExclusionFilter(Project{ExcludedJobTitles: []string{"Recruiter"}})
+
+		assert.Equal(t, excludedByJobTitle, filter.reason(Participant{JobTitle: "Senior Technical Recruiter"}))
+		assert.Equal(t, excludedByJobTitle, filter.reason(Participant{JobTitle: "Recruter"}))
+		assert.Equal(t, notExcluded, filter.reason(Participant{JobTitle: "Software Engineer"}))
+	})
+	t.Run("Given a project with exclusion keywords, When participants are filtered, Then participants with those words in their job title or industries must be excluded", func(t *testing.T) {
+		filter := newExclusionFilter(Project{ExclusionKeywords: []string{"Market Research", "HR"}})
+
+		assert.Equal(t, excludedByKeyword, filter.reason(Participant{JobTitle: "Market Research Analyst"}))
+		assert.Equal(t, excludedByKeyword, filter.reason(Participant{JobTitle: "Analyst", Industry: []string{"Market Research"}}))
+		assert.Equal(t, excludedByKeyword, filter.reason(Participant{JobTitle: "HR Business Partner"}))
+		assert.Equal(t, notExcluded, filter.reason(Participant{JobTitle: "Three Research Chairs"}))
+	})
+	t.Run("Given a participant excluded by several reasons, When exclusions are counted, Then must be counted once by the first reason", func(t *testing.T) {
+		filter := newExclusionFilter(Project{
+			ExcludedIndustries: []string{"Staffing and Recruiting"},
+			ExcludedJobTitles:  []string{"Recruiter"},
+			Seniority:          &SeniorityRange{Min: SenioritySenior, Strict: true},
+		})
+		excluded := Exclusions{}
+
+		excluded.add(filter.reason(Participant{JobTitle: "Recruiter", Industry: []string{"Staffing and Recruiting"}}))
+		excluded.add(filter.reason(Participant{JobTitle: "Junior Developer"}))
+		excluded.add(filter.reason(Participant{JobTitle: "Senior Developer"}))
+
+		assert.Equal(t, Exclusions{Industry: 1, Seniority: 1}, excluded)
+		assert.Equal(t, 2, excluded.Total())
+	})
+}
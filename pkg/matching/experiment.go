@@ -0,0 +1,101 @@
+package matching
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+//Variant is a named ScoreService of an Experiment, served to a share of the projects
+type Variant struct {
+	Name string
+	//Share is the weight of the variant in the traffic, relative to the shares of the other variants
+	Share float64
+	Score ScoreService
+}
+
+//Experiment splits projects between the scoring strategies of its variants, so their rankings can be
+//compared on live traffic. The first variant is the control
+type Experiment struct {
+	Name     string
+	variants []Variant
+	total    float64
+}
+
+//NewExperiment returns an Experiment with the given variants, or an error when they don't have
+//unique names and shares greater than 0
+func NewExperiment(name string, variants ...Variant) (*Experiment, error) {
+	if len(variants) == 0 {
+		return nil, errors.New("Invalid experiment: at least one variant is required")
+	}
+	problems := []string{}
+	names := map[string]bool{}
+	total := 0.0
+	for i, variant := range variants {
+		if strings.TrimSpace(variant.Name) == "" {
+			problems = append(problems, fmt.Sprintf("variants[%d] name is required", i))
+		} else if names[variant.Name] {
+			problems = append(problems, fmt.Sprintf("variants[%d] %s is repeated", i, variant.Name))
+		}
+		names[variant.Name] = true
+		if variant.Share <= 0 {
+			problems = append(problems, fmt.Sprintf("variants[%d] share must be greater than 0", i))
+		}
+		if variant.Score == nil {
+			problems = append(problems, fmt.Sprintf("variants[%d] score service is required", i))
+		}
+		total += variant.Share
+	}
+	if len(problems) > 0 {
+		return nil, errors.New("Invalid experiment: " + strings.Join(problems, "; "))
+	}
+	return &Experiment{Name: name, variants: variants, total: total}, nil
+}
+
+//Variants returns the variants of the experiment, the control first
+func (e *Experiment) Variants() []Variant {
+	return e.variants
+}
+
+//Assign returns the variant of the key, by its hash with the experiment name, so the same key always gets the
+//same variant and keys are split by the variant shares. An empty key gets the control
+func (e *Experiment) Assign(key string) Variant {
+	if key == "" {
+		return e.variants[0]
+	}
+	hash := fnv.New64a()
+	hash.Write([]byte(e.Name + "/" + key))
+	point := float64(hash.Sum64()%10000) / 10000 * e.total
+	for _, variant := range e.variants {
+		if point < variant.Share {
+			return variant
+		}
+		point -= variant.Share
+	}
+	return e.variants[len(e.variants)-1]
+}
+
+type experimentKeyContext struct{}
+
+//WithExperimentKey returns a copy of ctx with the key that assigns projects to variants, instead of their ID
+func WithExperimentKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, experimentKeyContext{}, key)
+}
+
+//ExperimentKey returns the key of the context that assigns projects to variants, or the project ID when it has none
+func ExperimentKey(ctx context.Context, project Project) string {
+	if key, ok := ctx.Value(experimentKeyContext{}).(string); ok && key != "" {
+		return key
+	}
+	return project.ID
+}
+
+//WithExperiment scores projects with the variant of the experiment they are assigned to, and tags their
+//results with the variant name
+func WithExperiment(experiment *Experiment) ActionOption {
+	return func(a *action) {
+		a.experiment = experiment
+	}
+}
@@ -0,0 +1,83 @@
+package matching
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExperiment(t *testing.T) {
+	control := Variant{Name: "control", Share: 1, Score: NewScoreService()}
+	titles := Variant{Name: "titles", Share: 3, Score: NewScoreService(WithScoreWeights(ScoreWeights{JobTitle: 1}))}
+	experiment, err := NewExperiment("job-titles", control, titles)
+	assert.Nil(t, err)
+
+	t.Run("Given an experiment, When keys are assigned, Then the same key must get the same variant and keys must be split by shares", func(t *testing.T) {
+		assigned := map[string]int{}
+		for i := 0; i < 4000; i++ {
+			key := fmt.Sprintf("project-%d", i)
+			variant := experiment.Assign(key)
+			assert.Equal(t, variant.Name, experiment.Assign(key).Name)
+			assigned[variant.Name]++
+		}
+
+		assert.InDelta(t, 1000, assigned["control"], 150)
+		assert.InDelta(t, 3000, assigned["titles"], 150)
+		assert.Equal(t, "control", experiment.Assign("").Name)
+	})
+	t.Run("Given invalid variants, When the experiment is created, Then must return every problem found", func(t *testing.T) {
+		_, err := NewExperiment("invalid", control, Variant{Name: "control", Score: NewScoreService()}, Variant{Share: 1})
+
+		assert.EqualError(t, err, "Invalid experiment: variants[1] control is repeated; variants[1] share must be greater than 0; variants[2] name is required; variants[2] score service is required")
+	})
+	t.Run("Given a context with an experiment key, When the key is taken, Then must be used instead of the project ID", func(t *testing.T) {
+		project := Project{ID: "p1"}
+
+		assert.Equal(t, "p1", ExperimentKey(context.Background(), project))
+		assert.Equal(t, "client-7", ExperimentKey(WithExperimentKey(context.Background(), "client-7"), project))
+	})
+	t.Run("Given an action with an experiment, When a page is requested, Then participants must be scored and tagged by the assigned variant", func(t *testing.T) {
+		participants := []Participant{
+			{ID: "1", JobTitle: "Java Developer", Industry: []string{"Retail"}, FormattedAddress: "New York, NY, USA"},
+			{ID: "2", JobTitle: "Cashier", Industry: []string{"Banking"}, FormattedAddress: "New York, NY, USA"},
+		}
+		repository := new(mockParticipantRepostory)
+		repository.On("GetByFormattedAddress", "New York, NY, USA").Return(participants, nil)
+		metrics := &fakeMetrics{}
+		action := NewMatchingParticipantsAction(repository, NewDistanceService(), NewScoreService(), WithExperiment(experiment), WithMetrics(metrics))
+		project := Project{
+			ProfessionalIndustry:  []string{"Banking"},
+			ProfessionalJobTitles: []string{"Java Developer"},
+			Cities:                []City{{CityLocation: CityLocation{FormattedAddress: "New York, NY, USA"}}},
+		}
+		key := ""
+		for i := 0; key == ""; i++ {
+			if experiment.Assign(fmt.Sprint(i)).Name == "titles" {
+				key = fmt.Sprint(i)
+			}
+		}
+
+		page, err := action.GetMatchingParticipantsPageForProject(WithExperimentKey(context.Background(), key), project, PageRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "titles", page.Variant)
+		assert.Equal(t, []string{"titles"}, metrics.variants)
+		assert.Equal(t, "1", page.Participants[0].ID)
+		assert.Equal(t, 0.0, page.Participants[1].Score)
+		for _, participant := range page.Participants {
+			assert.Equal(t, "titles", participant.Variant)
+		}
+	})
+	t.Run("Given a feedback recorder with an experiment, When feedback is recorded, Then must be tagged with the variant of the project", func(t *testing.T) {
+		repository := &feedbackList{}
+		recorder := NewFeedbackRecorder(participantsByID{"1": {ID: "1"}}, NewScoreService(), repository, WithFeedbackExperiment(experiment))
+		accepted := false
+
+		feedback, err := recorder.RecordFeedback(context.Background(), FeedbackRequest{Project: Project{ID: "p1"}, ParticipantID: "1", Accepted: &accepted})
+
+		assert.Nil(t, err)
+		assert.Equal(t, experiment.Assign("p1").Name, feedback.Variant)
+	})
+}
@@ -0,0 +1,124 @@
+package matching
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+//Feedback represents a recruiter accepting or rejecting a participant returned for a project,
+//with the score of every criteria the participant had, so a RankingModel can be trained with it
+type Feedback struct {
+	ProjectID     string `json:"projectId,omitempty"`
+	ParticipantID string `json:"participantId"`
+	Accepted      bool   `json:"accepted"`
+	//Variant is the experiment variant the project is assigned to, so variants can be compared by their feedback
+	Variant    string         `json:"variant,omitempty"`
+	Features   ScoreBreakdown `json:"features"`
+	RecordedAt time.Time      `json:"recordedAt"`
+}
+
+//FeedbackRequest represents the decision of a recruiter about a participant of a project
+type FeedbackRequest struct {
+	//ProjectID identifies the project in the client, it's only stored with the feedback
+	ProjectID     string  `json:"projectId,omitempty"`
+	Project       Project `json:"project"`
+	ParticipantID string  `json:"participantId"`
+	Accepted      *bool   `json:"accepted"`
+}
+
+//Validate returns a ValidationError describing every problem found in the FeedbackRequest, or nil when it's valid
+func (r FeedbackRequest) Validate() error {
+	problems := []string{}
+	if strings.TrimSpace(r.ParticipantID) == "" {
+		problems = append(problems, "participantId is required")
+	}
+	if r.Accepted == nil {
+		problems = append(problems, "accepted is required")
+	}
+	if len(problems) > 0 {
+		return ValidationError{Problems: problems, Subject: "feedback"}
+	}
+	return nil
+}
+
+//FeedbackRepository is an interface where Feedback is stored
+type FeedbackRepository interface {
+	Save(feedback Feedback) error
+}
+
+//FeedbackRecorder records the decisions of recruiters about participants
+type FeedbackRecorder interface {
+	RecordFeedback(ctx context.Context, request FeedbackRequest) (Feedback, error)
+}
+
+type feedbackRecorder struct {
+	participants ParticipantFinder
+	score        ScoreService
+	repository   FeedbackRepository
+	experiment   *Experiment
+	metrics      Metrics
+	now          func() time.Time
+}
+
+//FeedbackOption customizes a FeedbackRecorder built by NewFeedbackRecorder
+type FeedbackOption func(r *feedbackRecorder)
+
+//WithFeedbackExperiment tags the feedback with the variant of the experiment the project is assigned to,
+//the same way matching requests are assigned
+func WithFeedbackExperiment(experiment *Experiment) FeedbackOption {
+	return func(r *feedbackRecorder) {
+		r.experiment = experiment
+	}
+}
+
+//WithFeedbackMetrics records every feedback with the given Metrics
+func WithFeedbackMetrics(metrics Metrics) FeedbackOption {
+	return func(r *feedbackRecorder) {
+		r.metrics = metrics
+	}
+}
+
+//RecordFeedback stores the decision with the features of the participant for the project. Returns a ValidationError
+//when the request is invalid, and ErrParticipantNotFound when the participant doesn't exist
+func (r *feedbackRecorder) RecordFeedback(ctx context.Context, request FeedbackRequest) (Feedback, error) {
+	if err := request.Validate(); err != nil {
+		return Feedback{}, err
+	}
+	participant, err := r.participants.GetByID(request.ParticipantID)
+	if err != nil {
+		return Feedback{}, err
+	}
+
+	feedback := Feedback{
+		ProjectID:     request.ProjectID,
+		ParticipantID: participant.ID,
+		Accepted:      *request.Accepted,
+		Features:      r.score.GetMatchingFeatures(request.Project, participant),
+		RecordedAt:    r.now().UTC(),
+	}
+	if r.experiment != nil {
+		feedback.Variant = r.experiment.Assign(ExperimentKey(ctx, request.Project)).Name
+	}
+	if err := r.repository.Save(feedback); err != nil {
+		return Feedback{}, err
+	}
+	r.metrics.ObserveFeedback(feedback.Variant, feedback.Accepted)
+	return feedback, nil
+}
+
+//NewFeedbackRecorder returns a FeedbackRecorder that finds participants in the finder, takes their features
+//from the ScoreService, and stores the feedback in the repository
+func NewFeedbackRecorder(participants ParticipantFinder, score ScoreService, repository FeedbackRepository, options ...FeedbackOption) FeedbackRecorder {
+	r := &feedbackRecorder{
+		participants: participants,
+		score:        score,
+		repository:   repository,
+		metrics:      noopMetrics{},
+		now:          time.Now,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
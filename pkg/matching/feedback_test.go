@@ -0,0 +1,66 @@
+package matching
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type participantsByID map[string]Participant
+
+func (p participantsByID) GetByID(id string) (Participant, error) {
+	participant, ok := p[id]
+	if !ok {
+		return Participant{}, ErrParticipantNotFound
+	}
+	return participant, nil
+}
+
+type feedbackList struct {
+	feedback []Feedback
+}
+
+func (l *feedbackList) Save(feedback Feedback) error {
+	l.feedback = append(l.feedback, feedback)
+	return nil
+}
+
+func TestFeedbackRecorder(t *testing.T) {
+	participants := participantsByID{
+		"1": {ID: "1", JobTitle: "Java Developer", Industry: []string{"Banking"}, Gender: "female"},
+	}
+	project := Project{ProfessionalIndustry: []string{"Banking"}, ProfessionalJobTitles: []string{"Java Developer"}, Genders: "male"}
+	accepted := true
+
+	t.Run("Given a participant decision, When it's recorded, Then must store the unweighted score of every criteria", func(t *testing.T) {
+		repository := &feedbackList{}
+		recorder := NewFeedbackRecorder(participants, NewScoreService(), repository)
+
+		feedback, err := recorder.RecordFeedback(context.Background(), FeedbackRequest{ProjectID: "p1", Project: project, ParticipantID: "1", Accepted: &accepted})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []Feedback{feedback}, repository.feedback)
+		assert.Equal(t, "p1", feedback.ProjectID)
+		assert.True(t, feedback.Accepted)
+		assert.Equal(t, ScoreBreakdown{Industry: 1, JobTitle: 1}, feedback.Features)
+		assert.WithinDuration(t, time.Now(), feedback.RecordedAt, time.Minute)
+	})
+	t.Run("Given an unknown participant, When the decision is recorded, Then must return participant not found", func(t *testing.T) {
+		repository := &feedbackList{}
+		recorder := NewFeedbackRecorder(participants, NewScoreService(), repository)
+
+		_, err := recorder.RecordFeedback(context.Background(), FeedbackRequest{Project: project, ParticipantID: "2", Accepted: &accepted})
+
+		assert.Equal(t, ErrParticipantNotFound, err)
+		assert.Empty(t, repository.feedback)
+	})
+	t.Run("Given a request without participant nor decision, When it's recorded, Then must return what's missing", func(t *testing.T) {
+		recorder := NewFeedbackRecorder(participants, NewScoreService(), &feedbackList{})
+
+		_, err := recorder.RecordFeedback(context.Background(), FeedbackRequest{Project: project})
+
+		assert.EqualError(t, err, "Invalid feedback: participantId is required; accepted is required")
+	})
+}
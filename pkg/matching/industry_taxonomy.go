@@ -0,0 +1,212 @@
+package matching
+
+import (
+	"strings"
+)
+
+//DefaultSiblingCredit is the similarity of two different industries of the same group
+const DefaultSiblingCredit = 0.5
+
+//DefaultParentCredit is the similarity of an industry with the group it belongs to
+const DefaultParentCredit = 0.75
+
+//maxIndustryFragments is the maximum amount of comma separated fragments joined to find an industry,
+//like "Health", "Wellness and Fitness"
+const maxIndustryFragments = 3
+
+//Industry represents an industry, the other ways it's written, and the group of related industries it belongs to
+type Industry struct {
+	Name    string   `json:"name"`
+	Group   string   `json:"group"`
+	Aliases []string `json:"aliases"`
+}
+
+//IndustryTaxonomy resolves industries to canonical names and groups
+type IndustryTaxonomy struct {
+	industries    []Industry
+	byKey         map[string]int
+	groups        map[string]string
+	siblingCredit float64
+	parentCredit  float64
+}
+
+//industryKey returns the industry name lowercased, with "&" written as "and" and single spaces
+func industryKey(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "&", " and ")
+	return strings.Join(strings.Fields(name), " ")
+}
+
+//Canonical returns the Industry with the given name or alias
+func (t *IndustryTaxonomy) Canonical(name string) (Industry, bool) {
+	i, ok := t.byKey[industryKey(name)]
+	if !ok {
+		return Industry{}, false
+	}
+	return t.industries[i], true
+}
+
+//Normalize returns the canonical names of the given industries. Consecutive fragments of an industry
+//split by its commas are joined back, and industries not found are kept trimmed
+func (t *IndustryTaxonomy) Normalize(industries []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+
+	for i := 0; i < len(industries); {
+		found := false
+		for j := min(len(industries), i+maxIndustryFragments); j > i; j-- {
+			if industry, ok := t.Canonical(strings.Join(industries[i:j], ", ")); ok {
+				add(industry.Name)
+				i = j
+				found = true
+				break
+			}
+		}
+		if !found {
+			add(strings.TrimSpace(industries[i]))
+			i++
+		}
+	}
+	return normalized
+}
+
+//Similarity returns 1 for the same industry, the parent credit when the expected industry is the group
+//of the actual one, the sibling credit for different industries of the same group, and 0 otherwise.
+//Names of both an industry and a group, like Consumer Goods, are the same industry before they are a group
+func (t *IndustryTaxonomy) Similarity(expected string, actual string) float64 {
+	actualIndustry, ok := t.Canonical(actual)
+	if !ok {
+		if industryKey(expected) == industryKey(actual) {
+			return 1
+		}
+		return 0
+	}
+	expectedIndustry, isIndustry := t.Canonical(expected)
+	if isIndustry && expectedIndustry.Name == actualIndustry.Name {
+		return 1
+	}
+	if group, ok := t.groups[industryKey(expected)]; ok {
+		if group == actualIndustry.Group {
+			return t.parentCredit
+		}
+		return 0
+	}
+	if !isIndustry {
+		return 0
+	}
+	if expectedIndustry.Group != "" && expectedIndustry.Group == actualIndustry.Group {
+		return t.siblingCredit
+	}
+	return 0
+}
+
+//Covers returns true when the actual industry is the expected one, or belongs to the expected group
+func (t *IndustryTaxonomy) Covers(expected string, actual string) bool {
+	similarity := t.Similarity(expected, actual)
+	if _, ok := t.groups[industryKey(expected)]; ok {
+		return similarity > 0
+	}
+	return similarity == 1
+}
+
+//NewIndustryTaxonomy returns an IndustryTaxonomy with the given industries. Different industries of the same
+//group are as similar as siblingCredit, and an industry is as similar to its group as parentCredit
+func NewIndustryTaxonomy(industries []Industry, siblingCredit float64, parentCredit float64) *IndustryTaxonomy {
+	t := &IndustryTaxonomy{
+		industries:    industries,
+		byKey:         map[string]int{},
+		groups:        map[string]string{},
+		siblingCredit: siblingCredit,
+		parentCredit:  parentCredit,
+	}
+	for i, industry := range industries {
+		for _, name := range append([]string{industry.Name}, industry.Aliases...) {
+			t.byKey[industryKey(name)] = i
+		}
+		if industry.Group != "" {
+			t.groups[industryKey(industry.Group)] = industry.Group
+		}
+	}
+	return t
+}
+
+//DefaultIndustryTaxonomy returns the LinkedIn industries grouped as LinkedIn does
+func DefaultIndustryTaxonomy() *IndustryTaxonomy {
+	groups := []struct {
+		name       string
+		industries []string
+	}{
+		{"Technology", []string{
+			"Computer Software", "Information Technology and Services", "Internet", "Computer Hardware",
+			"Computer Networking", "Computer & Network Security", "Computer Games", "Semiconductors",
+			"Telecommunications", "Wireless", "Information Services", "Nanotechnology", "Program Development",
+		}},
+		{"Finance", []string{
+			"Banking", "Financial Services", "Investment Banking", "Investment Management", "Capital Markets",
+			"Insurance", "Venture Capital & Private Equity", "Accounting",
+		}},
+		{"Media", []string{
+			"Broadcast Media", "Entertainment", "Motion Pictures and Film", "Music", "Online Media", "Publishing",
+			"Newspapers", "Printing", "Photography", "Media Production", "Animation",
+		}},
+		{"Health", []string{
+			"Hospital & Health Care", "Health, Wellness and Fitness", "Medical Devices", "Medical Practice",
+			"Pharmaceuticals", "Biotechnology", "Mental Health Care", "Veterinary",
+		}},
+		{"Education", []string{
+			"E-Learning", "Education Management", "Primary/Secondary Education", "Higher Education",
+			"Professional Training & Coaching", "Research",
+		}},
+		{"Consumer Goods", []string{
+			"Apparel & Fashion", "Consumer Goods", "Consumer Electronics", "Cosmetics", "Food & Beverages",
+			"Sporting Goods", "Luxury Goods & Jewelry", "Food Production", "Furniture",
+		}},
+		{"Consumer Services", []string{
+			"Retail", "Supermarkets", "Consumer Services", "Hospitality", "Restaurants", "Sports",
+			"Leisure, Travel & Tourism", "Wholesale",
+		}},
+		{"Arts", []string{
+			"Arts and Crafts", "Fine Art", "Design", "Graphic Design", "Performing Arts", "Museums and Institutions",
+		}},
+		{"Corporate Services", []string{
+			"Management Consulting", "Market Research", "Marketing and Advertising", "Public Relations and Communications",
+			"Human Resources", "Staffing and Recruiting", "Legal Services", "Law Practice", "Outsourcing/Offshoring",
+		}},
+		{"Manufacturing", []string{
+			"Automotive", "Electrical/Electronic Manufacturing", "Mechanical or Industrial Engineering",
+			"Glass, Ceramics & Concrete", "Mining & Metals", "Machinery", "Chemicals", "Industrial Automation",
+		}},
+		{"Energy", []string{
+			"Oil & Energy", "Utilities", "Renewables & Environment", "Environmental Services",
+		}},
+		{"Government", []string{
+			"Government Administration", "Public Policy", "Military", "Law Enforcement", "International Affairs",
+		}},
+		{"Real Estate and Construction", []string{
+			"Real Estate", "Commercial Real Estate", "Construction", "Architecture & Planning", "Civil Engineering",
+		}},
+		{"Transportation", []string{
+			"Logistics and Supply Chain", "Transportation/Trucking/Railroad", "Airlines/Aviation", "Maritime",
+			"Package/Freight Delivery",
+		}},
+	}
+	aliases := map[string][]string{
+		"Information Technology and Services": {"IT Services", "IT"},
+		"Computer Software":                   {"Software"},
+		"Hospital & Health Care":              {"Healthcare", "Health Care"},
+		"Marketing and Advertising":           {"Marketing", "Advertising"},
+	}
+
+	industries := []Industry{}
+	for _, group := range groups {
+		for _, name := range group.industries {
+			industries = append(industries, Industry{Name: name, Group: group.name, Aliases: aliases[name]})
+		}
+	}
+	return NewIndustryTaxonomy(industries, DefaultSiblingCredit, DefaultParentCredit)
+}
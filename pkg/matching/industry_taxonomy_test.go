@@ -0,0 +1,46 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndustryTaxonomy(t *testing.T) {
+	taxonomy := NewIndustryTaxonomy([]Industry{
+		{Name: "Computer Software", Group: "Technology", Aliases: []string{"Software"}},
+		{Name: "Internet", Group: "Technology"},
+		{Name: "Health, Wellness and Fitness", Group: "Health"},
+		{Name: "Banking", Group: "Finance"},
+	}, 0.5, 0.75)
+
+	t.Run("Given industries split by their commas, When they are normalized, Then must join them back into the canonical names", func(t *testing.T) {
+		normalized := taxonomy.Normalize([]string{"Banking", "Health", " Wellness & Fitness", " software", "Basket Weaving"})
+
+		assert.Equal(t, []string{"Banking", "Health, Wellness and Fitness", "Computer Software", "Basket Weaving"}, normalized)
+	})
+	t.Run("Given repeated industries, When they are normalized, Then must keep them once", func(t *testing.T) {
+		assert.Equal(t, []string{"Computer Software"}, taxonomy.Normalize([]string{"Software", "Computer Software"}))
+	})
+	t.Run("Given industries, When similarity is evaluated, Then must give full credit to the same industry, and partial credit to its group and siblings", func(t *testing.T) {
+		assert.Equal(t, 1.0, taxonomy.Similarity("software", "Computer Software"))
+		assert.Equal(t, 0.75, taxonomy.Similarity("Technology", "Internet"))
+		assert.Equal(t, 0.5, taxonomy.Similarity("Computer Software", "Internet"))
+		assert.Equal(t, 0.0, taxonomy.Similarity("Computer Software", "Banking"))
+		assert.Equal(t, 0.0, taxonomy.Similarity("Finance", "Internet"))
+	})
+	t.Run("Given industries out of the taxonomy, When similarity is evaluated, Then must only match the same name", func(t *testing.T) {
+		assert.Equal(t, 1.0, taxonomy.Similarity("Basket Weaving", "basket weaving"))
+		assert.Equal(t, 0.0, taxonomy.Similarity("Basket Weaving", "Banking"))
+	})
+	t.Run("Given a name of both an industry and a group, When similarity is evaluated, Then must give full credit to the same industry and parent credit to the rest of the group", func(t *testing.T) {
+		consumerGoods := DefaultIndustryTaxonomy()
+
+		assert.Equal(t, 1.0, consumerGoods.Similarity("Consumer Goods", "Consumer Goods"))
+		assert.Equal(t, 0.75, consumerGoods.Similarity("Consumer Goods", "Cosmetics"))
+		assert.Equal(t, 0.5, consumerGoods.Similarity("Cosmetics", "Consumer Goods"))
+		assert.True(t, consumerGoods.Covers("Consumer Goods", "Consumer Goods"))
+		assert.True(t, consumerGoods.Covers("Consumer Goods", "Cosmetics"))
+		assert.False(t, consumerGoods.Covers("Cosmetics", "Consumer Goods"))
+	})
+}
@@ -0,0 +1,120 @@
+package matching
+
+import (
+	"math"
+	"strings"
+)
+
+//bm25K1 controls how fast the relevance of a term saturates with its frequency in a profile
+const bm25K1 = 1.2
+
+//bm25B controls how much the relevance of a term is reduced in long profiles
+const bm25B = 0.75
+
+type posting struct {
+	participant string
+	frequency   int
+}
+
+//InvertedIndex keeps, for every term of the participant profiles, the participants whose job title
+//or industries have it, so participants can be ranked by relevance with BM25
+type InvertedIndex struct {
+	postings      map[string][]posting
+	lengths       map[string]int
+	averageLength float64
+}
+
+//Documents returns the amount of participants indexed
+func (i *InvertedIndex) Documents() int {
+	return len(i.lengths)
+}
+
+//DocumentFrequency returns the amount of participants whose profile has the term
+func (i *InvertedIndex) DocumentFrequency(term string) int {
+	return len(i.postings[stemTerm(strings.ToLower(term))])
+}
+
+//BM25 returns the relevance of every participant with any of the terms, by participant ID. Terms found in
+//few profiles weigh more than terms found in most of them, and repeated terms are counted once
+func (i *InvertedIndex) BM25(terms []string) map[string]float64 {
+	scores := map[string]float64{}
+	documents := float64(i.Documents())
+	seen := map[string]bool{}
+	for _, term := range terms {
+		term = stemTerm(strings.ToLower(term))
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		postings := i.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		found := float64(len(postings))
+		idf := math.Log(1 + (documents-found+0.5)/(found+0.5))
+		for _, p := range postings {
+			frequency := float64(p.frequency)
+			length := float64(i.lengths[p.participant])
+			norm := 1 - bm25B + bm25B*length/i.averageLength
+			scores[p.participant] += idf * frequency * (bm25K1 + 1) / (frequency + bm25K1*norm)
+		}
+	}
+	return scores
+}
+
+//profileTerms returns the terms a participant is indexed by, the words of its job title and industries
+func profileTerms(participant Participant) []string {
+	return textTerms(participant.JobTitle + " " + strings.Join(participant.Industry, " "))
+}
+
+//textTerms returns the lowercased and stemmed words of a text, without stop words
+func textTerms(text string) []string {
+	terms := []string{}
+	for _, word := range keywordWords(text) {
+		if !titleStopWords[word] {
+			terms = append(terms, stemTerm(word))
+		}
+	}
+	return terms
+}
+
+//stemTerm removes the plural of a term, so "engineers" is indexed as "engineer"
+func stemTerm(term string) string {
+	switch {
+	case len(term) > 4 && strings.HasSuffix(term, "ies"):
+		return term[:len(term)-3] + "y"
+	case len(term) > 3 && strings.HasSuffix(term, "s") && !strings.HasSuffix(term, "ss") && !strings.HasSuffix(term, "us"):
+		return term[:len(term)-1]
+	}
+	return term
+}
+
+//NewInvertedIndex returns an InvertedIndex of the job titles and industries of the participants, by their ID
+func NewInvertedIndex(participants []Participant) *InvertedIndex {
+	index := &InvertedIndex{
+		postings: map[string][]posting{},
+		lengths:  map[string]int{},
+	}
+	total := 0
+	for _, participant := range participants {
+		terms := profileTerms(participant)
+		frequencies := map[string]int{}
+		order := []string{}
+		for _, term := range terms {
+			if frequencies[term] == 0 {
+				order = append(order, term)
+			}
+			frequencies[term]++
+		}
+		for _, term := range order {
+			index.postings[term] = append(index.postings[term], posting{participant: participant.ID, frequency: frequencies[term]})
+		}
+		index.lengths[participant.ID] = len(terms)
+		total += len(terms)
+	}
+	if len(participants) > 0 {
+		index.averageLength = float64(total) / float64(len(participants))
+	}
+	return index
+}
@@ -0,0 +1,99 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type staticIndex struct {
+	index *InvertedIndex
+}
+
+func (s staticIndex) Index() *InvertedIndex {
+	return s.index
+}
+
+type countingIndex struct {
+	index *InvertedIndex
+	calls int
+}
+
+func (c *countingIndex) Index() *InvertedIndex {
+	c.calls++
+	return c.index
+}
+
+func TestInvertedIndex(t *testing.T) {
+	participants := []Participant{
+		{ID: "1", JobTitle: "Software Developer", Industry: []string{"Computer Software"}},
+		{ID: "2", JobTitle: "Java Developer", Industry: []string{"Banking"}},
+		{ID: "3", JobTitle: "Kafka Engineer", Industry: []string{"Computer Software"}},
+		{ID: "4", JobTitle: "Web Developer", Industry: []string{"Internet"}},
+		{ID: "5", JobTitle: "Head of Sales", Industry: []string{"Retail"}},
+	}
+	index := NewInvertedIndex(participants)
+
+	t.Run("Given participants, When they are indexed, Then terms must be found by their lowercased and singular words", func(t *testing.T) {
+		assert.Equal(t, 5, index.Documents())
+		assert.Equal(t, 3, index.DocumentFrequency("Developers"))
+		assert.Equal(t, 1, index.DocumentFrequency("kafka"))
+		assert.Equal(t, 0, index.DocumentFrequency("of"))
+	})
+	t.Run("Given a rare and a common term, When participants are ranked with BM25, Then the rare term must weigh more", func(t *testing.T) {
+		scores := index.BM25([]string{"kafka", "developer"})
+
+		assert.Greater(t, scores["3"], scores["1"])
+		assert.Greater(t, scores["3"], scores["2"])
+		_, found := scores["5"]
+		assert.False(t, found)
+	})
+	t.Run("Given the README project, When relevance terms are taken, Then filler words of its name must be ignored", func(t *testing.T) {
+		project := Project{Name: "Looking for software engineers experienced with Kafka"}
+
+		assert.Equal(t, []string{"software", "engineer", "kafka"}, relevanceTerms(project))
+	})
+	t.Run("Given a score service with the index, When participants are scored, Then the most relevant must get the whole relevance weight", func(t *testing.T) {
+		service := NewScoreService(WithRelevanceIndex(staticIndex{index}))
+		project := Project{Name: "Looking for software engineers experienced with Kafka"}
+
+		kafka := service.GetMatchingScoreBreakdown(project, participants[2])
+		developer := service.GetMatchingScoreBreakdown(project, participants[0])
+		sales := service.GetMatchingScoreBreakdown(project, participants[4])
+
+		assert.Equal(t, 1.0, kafka.Relevance)
+		assert.Greater(t, developer.Relevance, 0.0)
+		assert.Less(t, developer.Relevance, kafka.Relevance)
+		assert.Equal(t, 0.0, sales.Relevance)
+	})
+	t.Run("Given a score service whose participants are loading, When participants are scored, Then relevance must not weigh", func(t *testing.T) {
+		service := NewScoreService(WithRelevanceIndex(staticIndex{}))
+		project := Project{Name: "Kafka", ProfessionalJobTitles: []string{"Kafka Engineer"}}
+
+		breakdown := service.GetMatchingScoreBreakdown(project, participants[2])
+		withoutIndex := NewScoreService().GetMatchingScoreBreakdown(project, participants[2])
+
+		assert.Equal(t, 0.0, breakdown.Relevance)
+		assert.Equal(t, withoutIndex, breakdown)
+	})
+	t.Run("Given the score of a project, When many participants are scored, Then their relevance must be computed once", func(t *testing.T) {
+		counting := &countingIndex{index: index}
+		score := NewScoreService(WithRelevanceIndex(counting)).ForProject(Project{Name: "Kafka"})
+
+		kafka := score.GetMatchingScoreBreakdown(participants[2])
+		sales := score.GetMatchingScoreBreakdown(participants[4])
+
+		assert.Equal(t, 1, counting.calls)
+		assert.Equal(t, 1.0, kafka.Relevance)
+		assert.Equal(t, 0.0, sales.Relevance)
+	})
+	t.Run("Given a project whose terms match no participant, When a participant matching everything else is scored, Then relevance must not weigh", func(t *testing.T) {
+		service := NewScoreService(WithRelevanceIndex(staticIndex{index}))
+		project := Project{Name: "Zookeepers", Education: []string{"Bachelor"}}
+
+		breakdown := service.GetMatchingScoreBreakdown(project, Participant{ID: "9", JobTitle: "Zookeeper", Education: "Bachelor"})
+
+		assert.Equal(t, 0.0, breakdown.Relevance)
+		assert.Equal(t, 1.0, breakdown.Total())
+	})
+}
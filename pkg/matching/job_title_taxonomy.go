@@ -0,0 +1,182 @@
+package matching
+
+import (
+	"strings"
+)
+
+//DefaultFamilyCredit is the similarity of two different roles of the same family
+const DefaultFamilyCredit = 0.5
+
+//minRoleCoverage is the minimum share of the words of a role name or synonym that a job title
+//must have to be taken as that role
+const minRoleCoverage = 0.9
+
+//Role represents a canonical job title, the other ways it's written, like abbreviations,
+//and the family of related roles it belongs to
+type Role struct {
+	Name     string   `json:"name"`
+	Synonyms []string `json:"synonyms"`
+	Family   string   `json:"family"`
+}
+
+type roleTitle struct {
+	role   int
+	tokens []string
+}
+
+//JobTitleTaxonomy resolves job titles to canonical roles
+type JobTitleTaxonomy struct {
+	roles        []Role
+	titles       []roleTitle
+	familyCredit float64
+}
+
+//Resolve returns the role whose name or synonym is best covered by the job title.
+//When several are covered, the one with more words wins, so "Java Developer" is preferred over "Developer"
+func (t *JobTitleTaxonomy) Resolve(title string) (Role, bool) {
+	return t.roleAt(t.resolve(title))
+}
+
+func (t *JobTitleTaxonomy) roleAt(role int) (Role, bool) {
+	if role == -1 {
+		return Role{}, false
+	}
+	return t.roles[role], true
+}
+
+//resolve returns the index of the role of the job title, or -1 when it has none
+func (t *JobTitleTaxonomy) resolve(title string) int {
+	tokens := normalizeTitle(title)
+	if len(tokens) == 0 {
+		return -1
+	}
+
+	best := -1
+	bestCoverage := 0.0
+	for i, roleTitle := range t.titles {
+		coverage := tokenSetSimilarity(roleTitle.tokens, tokens)
+		if coverage < minRoleCoverage {
+			continue
+		}
+		if best == -1 || coverage > bestCoverage ||
+			(coverage == bestCoverage && len(roleTitle.tokens) > len(t.titles[best].tokens)) {
+			best = i
+			bestCoverage = coverage
+		}
+	}
+	if best == -1 {
+		return -1
+	}
+	return t.titles[best].role
+}
+
+//Similarity returns 1 when both job titles resolve to the same role, the family credit
+//when they resolve to different roles of the same family, and 0 otherwise
+func (t *JobTitleTaxonomy) Similarity(expected string, actual string) float64 {
+	expectedRole, ok := t.Resolve(expected)
+	if !ok {
+		return 0
+	}
+	actualRole, ok := t.Resolve(actual)
+	if !ok {
+		return 0
+	}
+	if expectedRole.Name == actualRole.Name {
+		return 1
+	}
+	if expectedRole.Family != "" && strings.EqualFold(expectedRole.Family, actualRole.Family) {
+		return t.familyCredit
+	}
+	return 0
+}
+
+//NewJobTitleTaxonomy returns a JobTitleTaxonomy with the given roles. Different roles of the same
+//family are as similar as familyCredit, DefaultFamilyCredit when it's 0
+func NewJobTitleTaxonomy(roles []Role, familyCredit float64) *JobTitleTaxonomy {
+	if familyCredit == 0 {
+		familyCredit = DefaultFamilyCredit
+	}
+	t := &JobTitleTaxonomy{
+		roles:        roles,
+		familyCredit: familyCredit,
+	}
+	for i, role := range roles {
+		for _, title := range append([]string{role.Name}, role.Synonyms...) {
+			if tokens := normalizeTitle(title); len(tokens) > 0 {
+				t.titles = append(t.titles, roleTitle{role: i, tokens: tokens})
+			}
+		}
+	}
+	return t
+}
+
+//DefaultJobTitleTaxonomy returns the JobTitleTaxonomy used when no other one is loaded
+func DefaultJobTitleTaxonomy() *JobTitleTaxonomy {
+	return NewJobTitleTaxonomy([]Role{
+		{Name: "Software Engineer", Family: "Software Engineering", Synonyms: []string{
+			"SWE", "SDE", "Software Development Engineer", "Software Developer", "Developer", "Dev",
+			"Programmer", "Application Developer", "Software Dev",
+		}},
+		{Name: "Java Developer", Family: "Software Engineering", Synonyms: []string{
+			"Java Engineer", "Java Software Engineer", "Java Software Developer", "Java/J2EE Developer",
+			"J2EE Developer", "Java Full Stack Developer", "Java Dev", "Java Programmer",
+		}},
+		{Name: ".NET Developer", Family: "Software Engineering", Synonyms: []string{
+			"NET Engineer", "Dotnet Developer",
+		}},
+		{Name: "C++ Developer", Family: "Software Engineering", Synonyms: []string{
+			"C++ Engineer", "C++ Programmer",
+		}},
+		{Name: "Frontend Developer", Family: "Software Engineering", Synonyms: []string{
+			"Frontend Engineer", "UI Developer", "Web Developer",
+		}},
+		{Name: "Backend Developer", Family: "Software Engineering", Synonyms: []string{
+			"Backend Engineer",
+		}},
+		{Name: "Full Stack Developer", Family: "Software Engineering", Synonyms: []string{
+			"Full Stack Engineer",
+		}},
+		{Name: "Mobile Developer", Family: "Software Engineering", Synonyms: []string{
+			"iOS Developer", "Android Developer", "Mobile Engineer",
+		}},
+		{Name: "Software Architect", Family: "Software Engineering", Synonyms: []string{
+			"Application Architect", "Technical Architect", "Solutions Architect",
+		}},
+		{Name: "QA Engineer", Family: "Quality Assurance", Synonyms: []string{
+			"Quality Assurance Engineer", "Test Engineer", "SDET", "Software Engineer in Test", "Tester",
+		}},
+		{Name: "DevOps Engineer", Family: "Infrastructure", Synonyms: []string{
+			"Site Reliability Engineer", "SRE", "Platform Engineer", "Infrastructure Engineer",
+		}},
+		{Name: "Data Scientist", Family: "Data", Synonyms: []string{
+			"Machine Learning Engineer", "ML Engineer", "AI/ML Scientist", "Research Scientist",
+		}},
+		{Name: "Data Engineer", Family: "Data", Synonyms: []string{
+			"Big Data Engineer", "ETL Developer",
+		}},
+		{Name: "Data Analyst", Family: "Data", Synonyms: []string{
+			"Business Intelligence Analyst", "BI Analyst", "Business Intelligence Consultant",
+		}},
+		{Name: "Business Analyst", Family: "Business", Synonyms: []string{
+			"BA", "Business Systems Analyst",
+		}},
+		{Name: "Product Manager", Family: "Product", Synonyms: []string{
+			"PM", "Product Owner", "Associate Product Manager",
+		}},
+		{Name: "Account Manager", Family: "Sales", Synonyms: []string{
+			"Client Manager", "Key Account Manager",
+		}},
+		{Name: "Account Executive", Family: "Sales", Synonyms: []string{
+			"AE", "Sales Executive", "Sales Representative",
+		}},
+		{Name: "Chief Executive Officer", Family: "Executive", Synonyms: []string{
+			"CEO", "Founder", "Co-Founder",
+		}},
+		{Name: "Chief Marketing Officer", Family: "Executive", Synonyms: []string{
+			"CMO",
+		}},
+		{Name: "Chief Technology Officer", Family: "Executive", Synonyms: []string{
+			"CTO",
+		}},
+	}, DefaultFamilyCredit)
+}
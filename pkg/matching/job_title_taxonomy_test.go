@@ -0,0 +1,43 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobTitleTaxonomy(t *testing.T) {
+	taxonomy := NewJobTitleTaxonomy([]Role{
+		{Name: "Software Engineer", Family: "Software Engineering", Synonyms: []string{"SWE", "SDE", "Developer"}},
+		{Name: "Java Developer", Family: "Software Engineering", Synonyms: []string{"Java/J2EE Developer"}},
+		{Name: "Accountant", Family: "Finance", Synonyms: []string{"CPA"}},
+	}, 0.4)
+
+	t.Run("Given an abbreviation, When it's resolved, Then must return its canonical role", func(t *testing.T) {
+		role, ok := taxonomy.Resolve("Sr. SDE")
+
+		assert.True(t, ok)
+		assert.Equal(t, "Software Engineer", role.Name)
+	})
+	t.Run("Given a title covered by several synonyms, When it's resolved, Then must return the most specific role", func(t *testing.T) {
+		role, ok := taxonomy.Resolve("Senior Java Developer")
+
+		assert.True(t, ok)
+		assert.Equal(t, "Java Developer", role.Name)
+	})
+	t.Run("Given a title without role, When it's resolved, Then must not return a role", func(t *testing.T) {
+		_, ok := taxonomy.Resolve("Barista")
+
+		assert.False(t, ok)
+	})
+	t.Run("Given titles of the same role, When similarity is evaluated, Then must be a full match", func(t *testing.T) {
+		assert.Equal(t, 1.0, taxonomy.Similarity("Software Engineer", "SWE"))
+	})
+	t.Run("Given titles of the same family, When similarity is evaluated, Then must be the family credit", func(t *testing.T) {
+		assert.Equal(t, 0.4, taxonomy.Similarity("Software Engineer", "Java/J2EE Developer"))
+	})
+	t.Run("Given titles of different families or without role, When similarity is evaluated, Then must not match", func(t *testing.T) {
+		assert.Equal(t, 0.0, taxonomy.Similarity("Software Engineer", "CPA"))
+		assert.Equal(t, 0.0, taxonomy.Similarity("Software Engineer", "Barista"))
+	})
+}
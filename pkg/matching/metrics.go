@@ -0,0 +1,34 @@
+package matching
+
+import "time"
+
+//Metrics records measures of the matching process
+type Metrics interface {
+	ObserveCityLookup(city string, duration time.Duration)
+	ObserveResults(count int)
+	ObserveScore(score float64)
+	//ObserveVariant records a project scored by an experiment variant
+	ObserveVariant(variant string)
+	//ObserveFeedback records a recruiter decision about a participant scored by an experiment variant
+	ObserveFeedback(variant string, accepted bool)
+}
+
+type noopMetrics struct {
+}
+
+func (m noopMetrics) ObserveCityLookup(city string, duration time.Duration) {}
+
+func (m noopMetrics) ObserveResults(count int) {}
+
+func (m noopMetrics) ObserveScore(score float64) {}
+
+func (m noopMetrics) ObserveVariant(variant string) {}
+
+func (m noopMetrics) ObserveFeedback(variant string, accepted bool) {}
+
+//WithMetrics records the measures of the Action with the given Metrics
+func WithMetrics(metrics Metrics) ActionOption {
+	return func(a *action) {
+		a.metrics = metrics
+	}
+}
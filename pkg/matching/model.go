@@ -2,14 +2,51 @@ package matching
 
 //Project represent a Respondant project
 type Project struct {
+	//ID identifies the project in the client, it assigns the project to an experiment variant
+	ID string `json:"id,omitempty"`
+	//Name describes the project, its words are used to rank participants by relevance, like "Kafka"
+	Name                  string   `json:"name,omitempty"`
 	Cities                []City   `json:"cities"`
 	Genders               string   `json:"genders"`
 	ProfessionalIndustry  []string `json:"professionalIndustry"`
 	ProfessionalJobTitles []string `json:"professionalJobTitles"`
+	//JobTitleThreshold is the minimum similarity, from 0 to 1, for a job title to score. Defaults to DefaultJobTitleThreshold
+	JobTitleThreshold float64 `json:"jobTitleThreshold,omitempty"`
+	//Seniority is the range of seniority levels looked for, any level scores when it's not set
+	Seniority *SeniorityRange `json:"seniority,omitempty"`
+	//ExcludedIndustries filters out participants with any of these industries, or industries of these groups
+	ExcludedIndustries []string `json:"excludedIndustries,omitempty"`
+	//ExcludedJobTitles filters out participants whose job title matches any of these, like "Recruiter"
+	ExcludedJobTitles []string `json:"excludedJobTitles,omitempty"`
+	//ExclusionKeywords filters out participants with any of these words in their job title or industries
+	ExclusionKeywords []string `json:"exclusionKeywords,omitempty"`
+	//Education are the education levels looked for, like "Bachelor's Degree"
+	Education []string `json:"education,omitempty"`
+	//Required are the criteria participants must meet to be in the results, instead of adding to their score
+	Required []Criterion `json:"required,omitempty"`
+	//MinScore is the minimum score, from 0 to 1, for a participant to be in the results
+	MinScore float64 `json:"minScore,omitempty"`
+	//Query is a screening query participants must meet, like `industry:Banking AND NOT title:intern`
+	Query string `json:"query,omitempty"`
+	//Quotas are the min and max amount of participants selected by city, gender or industry
+	Quotas []Quota `json:"quotas,omitempty"`
+	//SelectionSize is the amount of participants selected, every participant within the quota maximums is selected when it's 0
+	SelectionSize int `json:"selectionSize,omitempty"`
+	//Fatigue filters out and penalizes the participants that took part in recent projects
+	Fatigue *FatigueRule `json:"fatigue,omitempty"`
+}
+
+func (p Project) jobTitleThreshold() float64 {
+	if p.JobTitleThreshold == 0 {
+		return DefaultJobTitleThreshold
+	}
+	return p.JobTitleThreshold
 }
 
 type City struct {
 	CityLocation CityLocation `json:"location"`
+	//Capacity is the most participants assigned to the city in allocation mode, it's unlimited when it's 0
+	Capacity int `json:"capacity,omitempty"`
 }
 
 type CityLocation struct {
@@ -28,10 +65,21 @@ type Location struct {
 
 //Participant represent a Respondent or project participant
 type Participant struct {
+	ID               string
 	Name             string
 	Gender           string
 	FormattedAddress string
 	Location         Location
 	JobTitle         string
 	Industry         []string
+	Seniority        Seniority
+	Education        string
+}
+
+//seniority returns the Seniority of the participant, parsed from its job title when it isn't set
+func (p Participant) seniority() Seniority {
+	if p.Seniority == SeniorityUnknown {
+		return ParseSeniorityLevel(p.JobTitle)
+	}
+	return p.Seniority
 }
@@ -0,0 +1,118 @@
+package matching
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+//DefaultPageLimit is the amount of results returned when a page doesn't specify a limit
+const DefaultPageLimit = 50
+
+//MaxPageLimit is the biggest amount of results that can be requested in a single page
+const MaxPageLimit = 500
+
+//ErrInvalidCursor is retrived when a cursor can't be decoded
+var ErrInvalidCursor = errors.New("Invalid cursor")
+
+//PageRequest represents the window of ranked results requested by a client
+type PageRequest struct {
+	Limit  int
+	Cursor string
+	//All returns every result in a single page, ignoring Limit
+	All bool
+}
+
+//ParticipantsPage represents a window of ranked MatchingParticipants
+type ParticipantsPage struct {
+	Participants []MatchingParticipant
+	NextCursor   string
+	Total        int
+	//Excluded counts the participants filtered out of the results by the project exclusions
+	Excluded Exclusions
+	//Variant is the name of the experiment variant that scored the participants
+	Variant string
+	//UnmetQuotas are the quotas of the project whose min couldn't be reached
+	UnmetQuotas []UnmetQuota
+	//Allocations are the participants assigned to every project city in allocation mode
+	Allocations []CityAllocation
+}
+
+//Cursor represents the position of the last MatchingParticipant returned in a page.
+//It's built with the score and the participant ID so it stays valid when participants are reloaded
+type Cursor struct {
+	Score float64
+	ID    string
+}
+
+//Encode returns an opaque representation of the Cursor that is safe to use in URLs
+func (c Cursor) Encode() string {
+	raw := strconv.FormatFloat(c.Score, 'g', -1, 64) + ":" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+//isBefore reports if a participant is ranked before the Cursor position
+func (c Cursor) isBefore(p MatchingParticipant) bool {
+	return p.Score < c.Score || (p.Score == c.Score && p.ID > c.ID)
+}
+
+//DecodeCursor returns the Cursor represented by an encoded string
+func DecodeCursor(encoded string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	score, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{
+		Score: score,
+		ID:    parts[1],
+	}, nil
+}
+
+func normalizePageLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return limit
+}
+
+func paginate(ranked []MatchingParticipant, request PageRequest) (ParticipantsPage, error) {
+	start := 0
+	if request.Cursor != "" {
+		cursor, err := DecodeCursor(request.Cursor)
+		if err != nil {
+			return ParticipantsPage{}, err
+		}
+		for start < len(ranked) && !cursor.isBefore(ranked[start]) {
+			start++
+		}
+	}
+
+	end := start + normalizePageLimit(request.Limit)
+	if request.All || end > len(ranked) {
+		end = len(ranked)
+	}
+
+	page := ParticipantsPage{
+		Participants: ranked[start:end],
+		Total:        len(ranked),
+	}
+	if end < len(ranked) {
+		last := ranked[end-1]
+		page.NextCursor = Cursor{Score: last.Score, ID: last.ID}.Encode()
+	}
+
+	return page, nil
+}
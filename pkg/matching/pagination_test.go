@@ -0,0 +1,62 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagination(t *testing.T) {
+	ranked := []MatchingParticipant{
+		MatchingParticipant{ID: "a", Score: 3},
+		MatchingParticipant{ID: "b", Score: 2},
+		MatchingParticipant{ID: "c", Score: 2},
+		MatchingParticipant{ID: "d", Score: 1},
+		MatchingParticipant{ID: "e", Score: 0},
+	}
+
+	t.Run("Given a cursor, When it's encoded and decoded, Then must keep score and ID", func(t *testing.T) {
+		cursor := Cursor{Score: 1.5, ID: "c0ffee"}
+
+		decoded, err := DecodeCursor(cursor.Encode())
+
+		assert.Nil(t, err)
+		assert.Equal(t, cursor, decoded)
+	})
+	t.Run("Given a malformed cursor, When it's decoded, Then must return an invalid cursor error", func(t *testing.T) {
+		_, err := DecodeCursor("not a cursor")
+
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+	t.Run("Given ranked participants, When the first page is requested, Then must return the limit and a cursor to the next page", func(t *testing.T) {
+		page, err := paginate(ranked, PageRequest{Limit: 2})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 5, page.Total)
+		assert.Equal(t, []MatchingParticipant{ranked[0], ranked[1]}, page.Participants)
+		assert.NotEqual(t, "", page.NextCursor)
+	})
+	t.Run("Given a cursor, When the next pages are requested, Then must continue after the cursor until the last page", func(t *testing.T) {
+		second, _ := paginate(ranked, PageRequest{Limit: 2, Cursor: Cursor{Score: 2, ID: "b"}.Encode()})
+		last, _ := paginate(ranked, PageRequest{Limit: 2, Cursor: second.NextCursor})
+
+		assert.Equal(t, []MatchingParticipant{ranked[2], ranked[3]}, second.Participants)
+		assert.Equal(t, []MatchingParticipant{ranked[4]}, last.Participants)
+		assert.Equal(t, "", last.NextCursor)
+	})
+	t.Run("Given a cursor of a participant that is no longer present, When the next page is requested, Then must continue from its ranking position", func(t *testing.T) {
+		page, _ := paginate(ranked, PageRequest{Limit: 2, Cursor: Cursor{Score: 2, ID: "bb"}.Encode()})
+
+		assert.Equal(t, []MatchingParticipant{ranked[2], ranked[3]}, page.Participants)
+	})
+	t.Run("Given a page without limit, When it's requested, Then must use the default limit", func(t *testing.T) {
+		assert.Equal(t, DefaultPageLimit, normalizePageLimit(0))
+		assert.Equal(t, MaxPageLimit, normalizePageLimit(MaxPageLimit+1))
+	})
+	t.Run("Given a page of every result, When it's requested, Then must return every participant without a cursor", func(t *testing.T) {
+		page, _ := paginate(ranked, PageRequest{Limit: 2, All: true})
+
+		assert.Equal(t, ranked, page.Participants)
+		assert.Equal(t, "", page.NextCursor)
+	})
+}
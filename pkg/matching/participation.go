@@ -0,0 +1,139 @@
+package matching
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+//ParticipationOutcome is how the participation of a participant in a project ended
+type ParticipationOutcome string
+
+const (
+	OutcomeInvited   ParticipationOutcome = "invited"
+	OutcomeCompleted ParticipationOutcome = "completed"
+	OutcomeDeclined  ParticipationOutcome = "declined"
+	OutcomeNoShow    ParticipationOutcome = "noShow"
+)
+
+//ParticipationOutcomes are every ParticipationOutcome a Participation can have
+var ParticipationOutcomes = []ParticipationOutcome{OutcomeInvited, OutcomeCompleted, OutcomeDeclined, OutcomeNoShow}
+
+//DefaultFatigueWindowDays is the amount of days participations are penalized when a FatigueRule doesn't set it
+const DefaultFatigueWindowDays = 90
+
+//Participation represents a participant taking part in a project
+type Participation struct {
+	ParticipantID string               `json:"participantId"`
+	ProjectID     string               `json:"projectId"`
+	Date          time.Time            `json:"date"`
+	Outcome       ParticipationOutcome `json:"outcome"`
+}
+
+//Validate returns a ValidationError describing every problem found in the Participation, or nil when it's valid
+func (p Participation) Validate() error {
+	problems := []string{}
+	if strings.TrimSpace(p.ParticipantID) == "" {
+		problems = append(problems, "participantId is required")
+	}
+	if strings.TrimSpace(p.ProjectID) == "" {
+		problems = append(problems, "projectId is required")
+	}
+	if p.Date.IsZero() {
+		problems = append(problems, "date is required")
+	}
+	known := false
+	for _, outcome := range ParticipationOutcomes {
+		if p.Outcome == outcome {
+			known = true
+		}
+	}
+	if !known {
+		problems = append(problems, fmt.Sprintf("outcome %q is unknown, use one of %s", p.Outcome, outcomeNames()))
+	}
+	if len(problems) > 0 {
+		return ValidationError{Problems: problems, Subject: "participation"}
+	}
+	return nil
+}
+
+func outcomeNames() string {
+	names := []string{}
+	for _, outcome := range ParticipationOutcomes {
+		names = append(names, string(outcome))
+	}
+	return strings.Join(names, ", ")
+}
+
+//ParticipationHistory is an interface where can access to the participations of a participant
+type ParticipationHistory interface {
+	GetByParticipantID(id string) ([]Participation, error)
+}
+
+//ParticipationRepository is an interface where the participations of participants are stored
+type ParticipationRepository interface {
+	ParticipationHistory
+	Save(participation Participation) error
+}
+
+//FatigueRule protects participants from being invited to project after project. Every participation counts
+//but the declined ones
+type FatigueRule struct {
+	//CooldownDays filters out participants with a participation in the last days, or a scheduled one
+	CooldownDays int `json:"cooldownDays,omitempty"`
+	//Penalty is subtracted from the score of a participant for every participation in the window, from 0 to 1
+	Penalty float64 `json:"penalty,omitempty"`
+	//WindowDays are the days participations are penalized. Defaults to DefaultFatigueWindowDays
+	WindowDays int `json:"windowDays,omitempty"`
+}
+
+func (r FatigueRule) windowDays() int {
+	if r.WindowDays == 0 {
+		return DefaultFatigueWindowDays
+	}
+	return r.WindowDays
+}
+
+//evaluate returns if the participations put the participant in cooldown, and the penalty of its score
+func (r FatigueRule) evaluate(participations []Participation, now time.Time) (bool, float64) {
+	cooldownSince := now.AddDate(0, 0, -r.CooldownDays)
+	windowSince := now.AddDate(0, 0, -r.windowDays())
+	penalty := 0.0
+	for _, participation := range participations {
+		if participation.Outcome == OutcomeDeclined {
+			continue
+		}
+		if r.CooldownDays > 0 && participation.Date.After(cooldownSince) {
+			return true, 0
+		}
+		if participation.Date.After(windowSince) {
+			penalty += r.Penalty
+		}
+	}
+	return false, penalty
+}
+
+//validateFatigue returns the problems of the fatigue rule of the project
+func (p Project) validateFatigue() []string {
+	problems := []string{}
+	if p.Fatigue == nil {
+		return problems
+	}
+	if p.Fatigue.CooldownDays < 0 {
+		problems = append(problems, "fatigue cooldownDays must not be negative")
+	}
+	if p.Fatigue.WindowDays < 0 {
+		problems = append(problems, "fatigue windowDays must not be negative")
+	}
+	if p.Fatigue.Penalty < 0 || p.Fatigue.Penalty > 1 {
+		problems = append(problems, "fatigue penalty must be between 0 and 1")
+	}
+	return problems
+}
+
+//WithParticipationHistory applies the fatigue rules of projects with the participations of the history
+func WithParticipationHistory(history ParticipationHistory) ActionOption {
+	return func(a *action) {
+		a.history = history
+	}
+}
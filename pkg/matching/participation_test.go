@@ -0,0 +1,77 @@
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type participationsByParticipant map[string][]Participation
+
+func (p participationsByParticipant) GetByParticipantID(id string) ([]Participation, error) {
+	return p[id], nil
+}
+
+func TestFatigueRule(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.AddDate(0, 0, -days)
+	}
+
+	t.Run("Given a participation within the cooldown days, When the rule is evaluated, Then the participant must be in cooldown", func(t *testing.T) {
+		rule := FatigueRule{CooldownDays: 30}
+
+		cooldown, _ := rule.evaluate([]Participation{{Date: daysAgo(10), Outcome: OutcomeCompleted}}, now)
+
+		assert.True(t, cooldown)
+	})
+	t.Run("Given a declined participation within the cooldown days, When the rule is evaluated, Then it must not count", func(t *testing.T) {
+		rule := FatigueRule{CooldownDays: 30, Penalty: 0.1}
+
+		cooldown, penalty := rule.evaluate([]Participation{{Date: daysAgo(10), Outcome: OutcomeDeclined}}, now)
+
+		assert.False(t, cooldown)
+		assert.Equal(t, 0.0, penalty)
+	})
+	t.Run("Given participations within and out of the window, When the rule is evaluated, Then only the ones within it must be penalized", func(t *testing.T) {
+		rule := FatigueRule{CooldownDays: 7, Penalty: 0.1, WindowDays: 60}
+
+		cooldown, penalty := rule.evaluate([]Participation{
+			{Date: daysAgo(20), Outcome: OutcomeCompleted},
+			{Date: daysAgo(50), Outcome: OutcomeNoShow},
+			{Date: daysAgo(80), Outcome: OutcomeCompleted},
+		}, now)
+
+		assert.False(t, cooldown)
+		assert.InDelta(t, 0.2, penalty, 0.000001)
+	})
+	t.Run("Given a rule without window days, When the rule is evaluated, Then participations must be penalized within the default window", func(t *testing.T) {
+		rule := FatigueRule{Penalty: 0.1}
+
+		_, penalty := rule.evaluate([]Participation{
+			{Date: daysAgo(DefaultFatigueWindowDays - 1), Outcome: OutcomeInvited},
+			{Date: daysAgo(DefaultFatigueWindowDays + 1), Outcome: OutcomeInvited},
+		}, now)
+
+		assert.InDelta(t, 0.1, penalty, 0.000001)
+	})
+}
+
+func TestParticipationValidation(t *testing.T) {
+	t.Run("Given an incomplete participation, When it's validated, Then must report every problem", func(t *testing.T) {
+		err := Participation{ParticipantID: "p1", Outcome: "finished"}.Validate()
+
+		assert.Equal(t, `Invalid participation: projectId is required; date is required; outcome "finished" is unknown, use one of invited, completed, declined, noShow`, err.Error())
+	})
+	t.Run("Given a project with an invalid fatigue rule, When it's validated, Then must report it", func(t *testing.T) {
+		project := Project{
+			Cities:  []City{City{CityLocation: CityLocation{FormattedAddress: "New York, NY, USA"}}},
+			Fatigue: &FatigueRule{CooldownDays: -1, Penalty: 2},
+		}
+
+		err := project.Validate()
+
+		assert.Equal(t, "Invalid project: fatigue cooldownDays must not be negative; fatigue penalty must be between 0 and 1", err.Error())
+	})
+}
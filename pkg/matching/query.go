@@ -0,0 +1,389 @@
+package matching
+
+import (
+	"fmt"
+	"strings"
+)
+
+//QueryError is retrived when a screening query can't be parsed. Position is the 1-based offset
+//of the query where the problem was found
+type QueryError struct {
+	Message  string `json:"message"`
+	Position int    `json:"position"`
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+//QueryField is a participant field a screening query term is matched with
+type QueryField string
+
+const (
+	QueryFieldIndustry  QueryField = "industry"
+	QueryFieldTitle     QueryField = "title"
+	QueryFieldSeniority QueryField = "seniority"
+	QueryFieldGender    QueryField = "gender"
+	QueryFieldEducation QueryField = "education"
+	QueryFieldCity      QueryField = "city"
+)
+
+var queryFields = []QueryField{
+	QueryFieldCity,
+	QueryFieldEducation,
+	QueryFieldGender,
+	QueryFieldIndustry,
+	QueryFieldSeniority,
+	QueryFieldTitle,
+}
+
+//queryFieldAliases are other names accepted for the query fields
+var queryFieldAliases = map[string]QueryField{
+	"jobtitle": QueryFieldTitle,
+}
+
+//QueryNode is a node of the syntax tree of a screening query
+type QueryNode interface {
+	//Matches returns true when the participant meets the node
+	Matches(participant Participant) bool
+	String() string
+}
+
+//AndNode matches participants meeting both of its nodes
+type AndNode struct {
+	Left  QueryNode
+	Right QueryNode
+}
+
+func (n AndNode) Matches(participant Participant) bool {
+	return n.Left.Matches(participant) && n.Right.Matches(participant)
+}
+
+func (n AndNode) String() string {
+	return "(" + n.Left.String() + " AND " + n.Right.String() + ")"
+}
+
+//OrNode matches participants meeting any of its nodes
+type OrNode struct {
+	Left  QueryNode
+	Right QueryNode
+}
+
+func (n OrNode) Matches(participant Participant) bool {
+	return n.Left.Matches(participant) || n.Right.Matches(participant)
+}
+
+func (n OrNode) String() string {
+	return "(" + n.Left.String() + " OR " + n.Right.String() + ")"
+}
+
+//NotNode matches participants not meeting its node
+type NotNode struct {
+	Node QueryNode
+}
+
+func (n NotNode) Matches(participant Participant) bool {
+	return !n.Node.Matches(participant)
+}
+
+func (n NotNode) String() string {
+	return "NOT " + n.Node.String()
+}
+
+//TermNode matches participants whose field has the value. Values are matched ignoring case and punctuation, by
+//whole words in the same order, and a value ending with "*" matches words starting with it. Industries also
+//match by their groups, and gender and seniority must be equal
+type TermNode struct {
+	Field QueryField
+	Value string
+}
+
+func (n TermNode) Matches(participant Participant) bool {
+	switch n.Field {
+	case QueryFieldIndustry:
+		for _, industry := range participant.Industry {
+			if defaultIndustries.Covers(n.Value, industry) || matchesWords(industry, n.Value) {
+				return true
+			}
+		}
+		return false
+	case QueryFieldTitle:
+		return matchesWords(participant.JobTitle, n.Value)
+	case QueryFieldSeniority:
+		return participant.seniority().String() == strings.ToLower(n.Value)
+	case QueryFieldGender:
+		return strings.EqualFold(strings.TrimSpace(participant.Gender), n.Value)
+	case QueryFieldEducation:
+		return matchesWords(participant.Education, n.Value)
+	case QueryFieldCity:
+		return matchesWords(participant.FormattedAddress, n.Value)
+	}
+	return false
+}
+
+func (n TermNode) String() string {
+	if strings.ContainsAny(n.Value, " \t():\"") {
+		return string(n.Field) + ":" + fmt.Sprintf("%q", n.Value)
+	}
+	return string(n.Field) + ":" + n.Value
+}
+
+//matchesWords returns true when the text has every word of the value one after the other.
+//The last word of the value matches any word starting with it when it ends with "*"
+func matchesWords(text string, value string) bool {
+	prefix := strings.HasSuffix(value, "*")
+	words := keywordWords(text)
+	valueWords := keywordWords(value)
+	if len(valueWords) == 0 {
+		return false
+	}
+	if !prefix {
+		return containsWords(words, valueWords)
+	}
+
+	last := len(valueWords) - 1
+	for i := 0; i+len(valueWords) <= len(words); i++ {
+		if containsWords(words[i:i+last], valueWords[:last]) && strings.HasPrefix(words[i+last], valueWords[last]) {
+			return true
+		}
+	}
+	return false
+}
+
+//Query represents a parsed screening query, like `(industry:Banking OR industry:Insurance) AND NOT title:intern`
+type Query struct {
+	Root QueryNode
+}
+
+//Matches returns true when the participant meets the query
+func (q *Query) Matches(participant Participant) bool {
+	return q.Root.Matches(participant)
+}
+
+func (q *Query) String() string {
+	return q.Root.String()
+}
+
+type queryTokenKind int
+
+const (
+	tokenEnd queryTokenKind = iota
+	tokenWord
+	tokenString
+	tokenOpen
+	tokenClose
+	tokenColon
+)
+
+type queryToken struct {
+	kind     queryTokenKind
+	text     string
+	position int
+}
+
+func (t queryToken) describe() string {
+	if t.kind == tokenEnd {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+//is returns true when the token is the given operator, written in any case
+func (t queryToken) is(operator string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, operator)
+}
+
+//tokenizeQuery splits a query in words, quoted values, parenthesis and colons
+func tokenizeQuery(query string) ([]queryToken, error) {
+	tokens := []queryToken{}
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, queryToken{kind: tokenOpen, text: "(", position: i + 1})
+			i++
+		case c == ')':
+			tokens = append(tokens, queryToken{kind: tokenClose, text: ")", position: i + 1})
+			i++
+		case c == ':':
+			tokens = append(tokens, queryToken{kind: tokenColon, text: ":", position: i + 1})
+			i++
+		case c == '"':
+			end := strings.IndexByte(query[i+1:], '"')
+			if end == -1 {
+				return nil, &QueryError{Message: "quoted value is not closed", Position: i + 1}
+			}
+			tokens = append(tokens, queryToken{kind: tokenString, text: query[i+1 : i+1+end], position: i + 1})
+			i += end + 2
+		default:
+			start := i
+			for i < len(query) && !strings.ContainsRune(" \t\n\r():\"", rune(query[i])) {
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: tokenWord, text: query[start:i], position: start + 1})
+		}
+	}
+	return append(tokens, queryToken{kind: tokenEnd, position: len(query) + 1}), nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	next   int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.next]
+}
+
+func (p *queryParser) take() queryToken {
+	token := p.tokens[p.next]
+	if token.kind != tokenEnd {
+		p.next++
+	}
+	return token
+}
+
+//parseOr parses terms joined by OR, which binds less than AND
+func (p *queryParser) parseOr() (QueryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("OR") {
+		p.take()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = OrNode{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (QueryNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is("AND") {
+		p.take()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = AndNode{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseNot() (QueryNode, error) {
+	if p.peek().is("NOT") {
+		p.take()
+		node, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return NotNode{Node: node}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *queryParser) parsePrimary() (QueryNode, error) {
+	token := p.take()
+	switch {
+	case token.kind == tokenOpen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.take(); closing.kind != tokenClose {
+			return nil, &QueryError{
+				Message:  fmt.Sprintf("expected \")\" to close the \"(\" at position %d, found %s", token.position, closing.describe()),
+				Position: closing.position,
+			}
+		}
+		return node, nil
+	case token.kind == tokenWord && !token.is("AND") && !token.is("OR"):
+		return p.parseTerm(token)
+	case token.kind == tokenEnd:
+		return nil, &QueryError{Message: "expected field:value or \"(\", found end of query", Position: token.position}
+	}
+	return nil, &QueryError{
+		Message:  fmt.Sprintf("expected field:value or \"(\", found %s", token.describe()),
+		Position: token.position,
+	}
+}
+
+func (p *queryParser) parseTerm(name queryToken) (QueryNode, error) {
+	if colon := p.peek(); colon.kind != tokenColon {
+		return nil, &QueryError{
+			Message:  fmt.Sprintf("expected field:value, found %s without a field, like title:%s", name.describe(), name.text),
+			Position: name.position,
+		}
+	}
+	field, ok := parseQueryField(name.text)
+	if !ok {
+		names := []string{}
+		for _, field := range queryFields {
+			names = append(names, string(field))
+		}
+		return nil, &QueryError{
+			Message:  fmt.Sprintf("unknown field %q, must be one of %s", name.text, strings.Join(names, ", ")),
+			Position: name.position,
+		}
+	}
+	p.take()
+
+	value := p.take()
+	//operators are only taken as values when they are quoted
+	operator := value.is("AND") || value.is("OR") || value.is("NOT")
+	if value.kind != tokenWord && value.kind != tokenString || operator || strings.TrimSpace(value.text) == "" {
+		return nil, &QueryError{
+			Message:  fmt.Sprintf("expected a value after %q, found %s", name.text+":", value.describe()),
+			Position: value.position,
+		}
+	}
+	if field == QueryFieldSeniority {
+		if _, err := ParseSeniority(value.text); err != nil {
+			return nil, &QueryError{Message: err.Error(), Position: value.position}
+		}
+	}
+	return TermNode{Field: field, Value: value.text}, nil
+}
+
+func parseQueryField(name string) (QueryField, bool) {
+	name = strings.ToLower(name)
+	if field, ok := queryFieldAliases[name]; ok {
+		return field, true
+	}
+	for _, field := range queryFields {
+		if string(field) == name {
+			return field, true
+		}
+	}
+	return "", false
+}
+
+//ParseQuery returns the Query represented by the text, or a *QueryError describing where it's wrong.
+//Terms are written field:value, with values in double quotes when they have spaces, and joined with
+//AND, OR, NOT and parenthesis. NOT binds more than AND, and AND more than OR
+func ParseQuery(text string) (*Query, error) {
+	tokens, err := tokenizeQuery(text)
+	if err != nil {
+		return nil, err
+	}
+	parser := &queryParser{tokens: tokens}
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := parser.peek(); token.kind != tokenEnd {
+		message := fmt.Sprintf("expected AND, OR or end of query, found %s", token.describe())
+		if token.kind == tokenClose {
+			message = "found \")\" without a \"(\" to close"
+		}
+		return nil, &QueryError{Message: message, Position: token.position}
+	}
+	return &Query{Root: root}, nil
+}
@@ -0,0 +1,84 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	javaBanker := Participant{
+		JobTitle:         "Senior Java Developer",
+		Industry:         []string{"Banking", "Computer Software"},
+		Gender:           "female",
+		FormattedAddress: "New York, NY, USA",
+	}
+	javaIntern := Participant{
+		JobTitle: "Java Intern",
+		Industry: []string{"Insurance"},
+		Gender:   "male",
+	}
+	retailAnalyst := Participant{
+		JobTitle: "Data Analyst",
+		Industry: []string{"Retail"},
+	}
+
+	t.Run("Given a query with AND, OR, NOT and parenthesis, When it's parsed, Then NOT must bind more than AND, and AND more than OR", func(t *testing.T) {
+		query, err := ParseQuery(`(industry:Banking OR industry:Insurance) AND title:"Java" AND NOT title:intern OR gender:male`)
+
+		assert.Nil(t, err)
+		assert.Equal(t, `((((industry:Banking OR industry:Insurance) AND title:Java) AND NOT title:intern) OR gender:male)`, query.String())
+	})
+	t.Run("Given a query, When participants are matched, Then only participants meeting it must match", func(t *testing.T) {
+		query, err := ParseQuery(`(industry:Banking OR industry:Insurance) AND title:"Java" AND NOT title:intern`)
+
+		assert.Nil(t, err)
+		assert.True(t, query.Matches(javaBanker))
+		assert.False(t, query.Matches(javaIntern))
+		assert.False(t, query.Matches(retailAnalyst))
+	})
+	t.Run("Given query terms of every field, When participants are matched, Then values must be matched by words, groups and levels", func(t *testing.T) {
+		terms := map[string]bool{
+			`industry:finance`:                    true,
+			`industry:"computer software"`:        true,
+			`industry:soft*`:                      true,
+			`title:"java developer"`:              true,
+			`title:"developer java"`:              false,
+			`title:dev*`:                          true,
+			`title:Jav`:                           false,
+			`seniority:senior`:                    true,
+			`gender:FEMALE`:                       true,
+			`city:"new york"`:                     true,
+			`education:master*`:                   false,
+			`jobTitle:java and not seniority:mid`: true,
+		}
+
+		for text, expected := range terms {
+			query, err := ParseQuery(text)
+			assert.Nil(t, err, text)
+			assert.Equal(t, expected, query.Matches(javaBanker), text)
+		}
+	})
+	t.Run("Given invalid queries, When they are parsed, Then must return what's wrong and where", func(t *testing.T) {
+		queries := map[string]QueryError{
+			`industry:Banking AND`:            QueryError{Message: `expected field:value or "(", found end of query`, Position: 21},
+			`(industry:Banking OR title:Java`: QueryError{Message: `expected ")" to close the "(" at position 1, found end of query`, Position: 32},
+			`industry:Banking)`:               QueryError{Message: `found ")" without a "(" to close`, Position: 17},
+			`titel:Java`:                      QueryError{Message: `unknown field "titel", must be one of city, education, gender, industry, seniority, title`, Position: 1},
+			`Banking`:                         QueryError{Message: `expected field:value, found "Banking" without a field, like title:Banking`, Position: 1},
+			`title: AND industry:Banking`:     QueryError{Message: `expected a value after "title:", found "AND"`, Position: 8},
+			`title:"Java`:                     QueryError{Message: `quoted value is not closed`, Position: 7},
+			`title:Java industry:Banking`:     QueryError{Message: `expected AND, OR or end of query, found "industry"`, Position: 12},
+			`seniority:guru`:                  QueryError{Message: `unknown seniority "guru", must be one of intern, junior, mid, senior, staff, lead, manager, director, executive`, Position: 11},
+		}
+
+		for text, expected := range queries {
+			_, err := ParseQuery(text)
+			queryError, ok := err.(*QueryError)
+			assert.True(t, ok, text)
+			if ok {
+				assert.Equal(t, expected, *queryError, text)
+			}
+		}
+	})
+}
@@ -0,0 +1,214 @@
+package matching
+
+import (
+	"fmt"
+	"strings"
+)
+
+//QuotaField is the attribute of participants a Quota counts them by
+type QuotaField string
+
+const (
+	QuotaCity     QuotaField = "city"
+	QuotaGender   QuotaField = "gender"
+	QuotaIndustry QuotaField = "industry"
+)
+
+//QuotaFields are every QuotaField a Quota can use
+var QuotaFields = []QuotaField{QuotaCity, QuotaGender, QuotaIndustry}
+
+//Quota limits how many of the selected participants have a value, like at least 3 participants from Miami
+//or at most 5 from New York
+type Quota struct {
+	Field QuotaField `json:"field"`
+	//Value is a project city, by its id, city or formattedAddress, a gender, or an industry or industry group
+	Value string `json:"value"`
+	Min   int    `json:"min,omitempty"`
+	//Max is the maximum amount of selected participants with the value, there is no maximum when it's 0
+	Max int `json:"max,omitempty"`
+}
+
+//UnmetQuota represents a Quota whose min couldn't be reached with the matching participants
+type UnmetQuota struct {
+	Quota    Quota `json:"quota"`
+	Selected int   `json:"selected"`
+}
+
+//hasQuotas returns true when the results of the project go through the selection stage
+func (p Project) hasQuotas() bool {
+	return len(p.Quotas) > 0 || p.SelectionSize > 0
+}
+
+//validateQuotas returns the problems of the quotas and selectionSize of the project
+func (p Project) validateQuotas() []string {
+	problems := []string{}
+	if p.SelectionSize < 0 {
+		problems = append(problems, "selectionSize must not be negative")
+	}
+	for i, quota := range p.Quotas {
+		known := false
+		for _, field := range QuotaFields {
+			if quota.Field == field {
+				known = true
+			}
+		}
+		if !known {
+			problems = append(problems, fmt.Sprintf("quotas[%d] field %q is unknown, use one of %s", i, quota.Field, quotaFieldNames()))
+		}
+		if strings.TrimSpace(quota.Value) == "" {
+			problems = append(problems, fmt.Sprintf("quotas[%d] value is required", i))
+		} else if quota.Field == QuotaCity && p.quotaCity(quota.Value) == "" {
+			problems = append(problems, fmt.Sprintf("quotas[%d] city %q is not a project city", i, quota.Value))
+		}
+		if quota.Min < 0 || quota.Max < 0 {
+			problems = append(problems, fmt.Sprintf("quotas[%d] min and max must not be negative", i))
+		}
+		if quota.Max > 0 && quota.Min > quota.Max {
+			problems = append(problems, fmt.Sprintf("quotas[%d] min must not be above max", i))
+		}
+	}
+	return problems
+}
+
+func quotaFieldNames() string {
+	names := []string{}
+	for _, field := range QuotaFields {
+		names = append(names, string(field))
+	}
+	return strings.Join(names, ", ")
+}
+
+//quotaCity returns the formatted address of the project city with the given id, city or formatted address,
+//or an empty string when the project doesn't have it
+func (p Project) quotaCity(value string) string {
+	value = strings.TrimSpace(value)
+	for _, city := range p.Cities {
+		location := city.CityLocation
+		if (location.ID != "" && location.ID == value) || strings.EqualFold(location.City, value) || strings.EqualFold(location.FormattedAddress, value) {
+			return location.FormattedAddress
+		}
+	}
+	return ""
+}
+
+//quotaCounter counts the selected participants of a Quota
+type quotaCounter struct {
+	quota    Quota
+	city     string
+	selected int
+}
+
+func (c *quotaCounter) counts(participant MatchingParticipant, profile Participant) bool {
+	switch c.quota.Field {
+	case QuotaCity:
+		return participant.City == c.city
+	case QuotaGender:
+		return strings.EqualFold(strings.TrimSpace(profile.Gender), strings.TrimSpace(c.quota.Value))
+	case QuotaIndustry:
+		for _, industry := range profile.Industry {
+			if defaultIndustries.Covers(c.quota.Value, industry) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//selection picks the participants of a ranking that meet the quotas of a project
+type selection struct {
+	counters []*quotaCounter
+}
+
+func newSelection(project Project) *selection {
+	s := &selection{}
+	for _, quota := range project.Quotas {
+		s.counters = append(s.counters, &quotaCounter{quota: quota, city: project.quotaCity(quota.Value)})
+	}
+	return s
+}
+
+//fits returns true when selecting the participant doesn't go over the max of any quota
+func (s *selection) fits(participant MatchingParticipant, profile Participant) bool {
+	for _, counter := range s.counters {
+		if counter.quota.Max > 0 && counter.selected >= counter.quota.Max && counter.counts(participant, profile) {
+			return false
+		}
+	}
+	return true
+}
+
+//needs returns the amount of quotas below their min the participant counts for
+func (s *selection) needs(participant MatchingParticipant, profile Participant) int {
+	needed := 0
+	for _, counter := range s.counters {
+		if counter.selected < counter.quota.Min && counter.counts(participant, profile) {
+			needed++
+		}
+	}
+	return needed
+}
+
+func (s *selection) add(participant MatchingParticipant, profile Participant) {
+	for _, counter := range s.counters {
+		if counter.counts(participant, profile) {
+			counter.selected++
+		}
+	}
+}
+
+//selectParticipants returns the participants of the ranking picked by the quotas of the project, still sorted by score,
+//and the quotas whose min couldn't be reached. While quotas are below their min, the participant counting for most
+//of them is picked, the best one on ties, so a participant meeting several mins saves room for the rest. Then the
+//best of the rest are picked until the selectionSize, skipping the participants of the quotas at their max
+func selectParticipants(project Project, ranked []MatchingParticipant, profiles map[string]Participant) ([]MatchingParticipant, []UnmetQuota) {
+	s := newSelection(project)
+	size := project.SelectionSize
+	if size == 0 {
+		size = len(ranked)
+	}
+	picked := make([]bool, len(ranked))
+	count := 0
+	pick := func(i int) {
+		s.add(ranked[i], profiles[ranked[i].ID])
+		picked[i] = true
+		count++
+	}
+	for count < size {
+		best, bestNeeded := -1, 0
+		for i, participant := range ranked {
+			profile := profiles[participant.ID]
+			if picked[i] || !s.fits(participant, profile) {
+				continue
+			}
+			if needed := s.needs(participant, profile); needed > bestNeeded {
+				best, bestNeeded = i, needed
+			}
+		}
+		if best < 0 {
+			break
+		}
+		pick(best)
+	}
+	for i, participant := range ranked {
+		if count >= size {
+			break
+		}
+		if !picked[i] && s.fits(participant, profiles[participant.ID]) {
+			pick(i)
+		}
+	}
+
+	selected := []MatchingParticipant{}
+	for i, participant := range ranked {
+		if picked[i] {
+			selected = append(selected, participant)
+		}
+	}
+	unmet := []UnmetQuota{}
+	for _, counter := range s.counters {
+		if counter.selected < counter.quota.Min {
+			unmet = append(unmet, UnmetQuota{Quota: counter.quota, Selected: counter.selected})
+		}
+	}
+	return selected, unmet
+}
@@ -0,0 +1,103 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectParticipants(t *testing.T) {
+	newYork := City{CityLocation: CityLocation{ID: "nyc", City: "New York", FormattedAddress: "New York, NY, USA"}}
+	miami := City{CityLocation: CityLocation{ID: "mia", City: "Miami", FormattedAddress: "Miami, FL, USA"}}
+	ranked := []MatchingParticipant{
+		{ID: "ana", Score: 0.9, City: "New York, NY, USA"},
+		{ID: "bob", Score: 0.8, City: "New York, NY, USA"},
+		{ID: "carl", Score: 0.7, City: "New York, NY, USA"},
+		{ID: "dana", Score: 0.4, City: "Miami, FL, USA"},
+		{ID: "eve", Score: 0.3, City: "Miami, FL, USA"},
+	}
+	profiles := map[string]Participant{
+		"ana":  {ID: "ana", Gender: "female", Industry: []string{"Banking"}},
+		"bob":  {ID: "bob", Gender: "male", Industry: []string{"Computer Software"}},
+		"carl": {ID: "carl", Gender: "male", Industry: []string{"Banking"}},
+		"dana": {ID: "dana", Gender: "female", Industry: []string{"Retail"}},
+		"eve":  {ID: "eve", Gender: "Female", Industry: []string{"Insurance"}},
+	}
+	ids := func(participants []MatchingParticipant) []string {
+		result := []string{}
+		for _, participant := range participants {
+			result = append(result, participant.ID)
+		}
+		return result
+	}
+
+	t.Run("Given a selection size and a city min, When participants are selected, Then the best of the city must be picked before better ones of other cities", func(t *testing.T) {
+		project := Project{
+			Cities:        []City{newYork, miami},
+			Quotas:        []Quota{{Field: QuotaCity, Value: "Miami", Min: 1}},
+			SelectionSize: 3,
+		}
+
+		selected, unmet := selectParticipants(project, ranked, profiles)
+
+		assert.Equal(t, []string{"ana", "bob", "dana"}, ids(selected))
+		assert.Equal(t, []UnmetQuota{}, unmet)
+	})
+	t.Run("Given a city max, When participants are selected, Then participants of the city over the max must be left out", func(t *testing.T) {
+		project := Project{
+			Cities: []City{newYork, miami},
+			Quotas: []Quota{{Field: QuotaCity, Value: "nyc", Max: 1}},
+		}
+
+		selected, _ := selectParticipants(project, ranked, profiles)
+
+		assert.Equal(t, []string{"ana", "dana", "eve"}, ids(selected))
+	})
+	t.Run("Given gender and industry quotas, When participants are selected, Then genders must match ignoring case and industries by their group", func(t *testing.T) {
+		project := Project{
+			Cities: []City{newYork, miami},
+			Quotas: []Quota{
+				{Field: QuotaGender, Value: "female", Min: 3},
+				{Field: QuotaIndustry, Value: "Banking", Max: 1},
+			},
+			SelectionSize: 4,
+		}
+
+		selected, unmet := selectParticipants(project, ranked, profiles)
+
+		assert.Equal(t, []string{"ana", "bob", "dana", "eve"}, ids(selected))
+		assert.Equal(t, []UnmetQuota{}, unmet)
+	})
+	t.Run("Given a min that can't be reached, When participants are selected, Then must report the quota with the selected amount", func(t *testing.T) {
+		quota := Quota{Field: QuotaCity, Value: "Miami, FL, USA", Min: 3}
+		project := Project{
+			Cities: []City{newYork, miami},
+			Quotas: []Quota{quota},
+		}
+
+		selected, unmet := selectParticipants(project, ranked, profiles)
+
+		assert.Equal(t, 5, len(selected))
+		assert.Equal(t, []UnmetQuota{{Quota: quota, Selected: 2}}, unmet)
+	})
+	t.Run("Given a participant meeting two mins ranked below one meeting a single min, When one participant is selected, Then must pick the one meeting both", func(t *testing.T) {
+		project := Project{
+			Cities: []City{newYork},
+			Quotas: []Quota{
+				{Field: QuotaGender, Value: "female", Min: 1},
+				{Field: QuotaIndustry, Value: "Banking", Min: 1},
+			},
+			SelectionSize: 1,
+		}
+		ranked := []MatchingParticipant{{ID: "tess", Score: 0.9}, {ID: "bea", Score: 0.8}}
+		profiles := map[string]Participant{
+			"tess": {ID: "tess", Gender: "female", Industry: []string{"Computer Software"}},
+			"bea":  {ID: "bea", Gender: "female", Industry: []string{"Banking"}},
+		}
+
+		selected, unmet := selectParticipants(project, ranked, profiles)
+
+		assert.Equal(t, []string{"bea"}, ids(selected))
+		assert.Equal(t, []UnmetQuota{}, unmet)
+	})
+}
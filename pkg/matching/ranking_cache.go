@@ -0,0 +1,87 @@
+package matching
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const rankingCacheTTL = 5 * time.Minute
+const rankingCacheSize = 128
+
+//ranking represents the participants of a project sorted by score, the ones filtered out,
+//the quotas of the project that couldn't be met and the participants assigned to every city
+type ranking struct {
+	participants []MatchingParticipant
+	excluded     Exclusions
+	unmetQuotas  []UnmetQuota
+	allocations  []CityAllocation
+}
+
+type rankingCacheEntry struct {
+	ranked    ranking
+	expiresAt time.Time
+}
+
+//rankingCache keeps the ranked results of recent projects so the following pages
+//of a project can be served without scoring every participant again
+type rankingCache struct {
+	mu      sync.Mutex
+	entries map[string]rankingCacheEntry
+	now     func() time.Time
+}
+
+func (c *rankingCache) get(key string) (ranking, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return ranking{}, false
+	}
+	if c.now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return ranking{}, false
+	}
+	return entry.ranked, true
+}
+
+func (c *rankingCache) put(key string, ranked ranking) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.entries) >= rankingCacheSize {
+		for k, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	if len(c.entries) >= rankingCacheSize {
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = rankingCacheEntry{
+		ranked:    ranked,
+		expiresAt: now.Add(rankingCacheTTL),
+	}
+}
+
+func projectKey(project Project) string {
+	encoded, err := json.Marshal(project)
+	if err != nil {
+		return ""
+	}
+	sum := sha1.Sum(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+func newRankingCache() *rankingCache {
+	return &rankingCache{
+		entries: map[string]rankingCacheEntry{},
+		now:     time.Now,
+	}
+}
@@ -0,0 +1,137 @@
+package matching
+
+import (
+	"errors"
+	"math"
+)
+
+//ErrNotEnoughFeedback is retrived when a RankingModel is trained without accepted and rejected participants
+var ErrNotEnoughFeedback = errors.New("Feedback must have accepted and rejected participants")
+
+//RankingModel represents a logistic regression of the probability of a participant being accepted,
+//fitted on the score of every criteria from recruiter Feedback
+type RankingModel struct {
+	Weights ScoreWeights `json:"weights"`
+	Bias    float64      `json:"bias"`
+	//Examples is the amount of feedback the model was trained with
+	Examples int `json:"examples"`
+	//Accuracy is the share of the training feedback the model predicts right
+	Accuracy float64 `json:"accuracy"`
+}
+
+//Probability returns the probability, from 0 to 1, of a participant with these features being accepted
+func (m RankingModel) Probability(features ScoreBreakdown) float64 {
+	return sigmoid(m.Bias + dot(m.Weights.values(), features.values()))
+}
+
+//ScoreWeights returns the learned weights to rank participants with. Criteria that make participants less
+//likely to be accepted don't add to the score, and the DefaultScoreWeights are used when none does
+func (m RankingModel) ScoreWeights() ScoreWeights {
+	values := m.Weights.values()
+	for i, value := range values {
+		values[i] = math.Max(0, value)
+	}
+	weights := scoreWeightsOf(values)
+	if weights.total() == 0 {
+		return DefaultScoreWeights
+	}
+	return weights
+}
+
+//TrainingOptions control how a RankingModel is fitted
+type TrainingOptions struct {
+	//Epochs is the amount of passes of gradient descent over the feedback
+	Epochs       int
+	LearningRate float64
+	//L2 penalizes large weights, so criteria seen in few feedback don't dominate
+	L2 float64
+}
+
+//DefaultTrainingOptions are the TrainingOptions used when no other ones are given
+var DefaultTrainingOptions = TrainingOptions{Epochs: 2000, LearningRate: 0.5, L2: 0.001}
+
+//TrainRankingModel fits a RankingModel to the feedback with batch gradient descent. The feedback must have accepted
+//and rejected participants, otherwise ErrNotEnoughFeedback is retrived. Training is deterministic, so the same
+//feedback always gives the same model
+func TrainRankingModel(feedback []Feedback, options TrainingOptions) (RankingModel, error) {
+	accepted := 0
+	for _, f := range feedback {
+		if f.Accepted {
+			accepted++
+		}
+	}
+	if accepted == 0 || accepted == len(feedback) {
+		return RankingModel{}, ErrNotEnoughFeedback
+	}
+
+	features := make([][]float64, len(feedback))
+	for i, f := range feedback {
+		features[i] = f.Features.values()
+	}
+	weights := make([]float64, len(features[0]))
+	bias := 0.0
+	examples := float64(len(feedback))
+	for epoch := 0; epoch < options.Epochs; epoch++ {
+		gradient := make([]float64, len(weights))
+		biasGradient := 0.0
+		for i, f := range feedback {
+			loss := sigmoid(bias+dot(weights, features[i])) - label(f.Accepted)
+			for j, value := range features[i] {
+				gradient[j] += loss * value
+			}
+			biasGradient += loss
+		}
+		for j := range weights {
+			weights[j] -= options.LearningRate * (gradient[j]/examples + options.L2*weights[j])
+		}
+		bias -= options.LearningRate * biasGradient / examples
+	}
+
+	model := RankingModel{Weights: scoreWeightsOf(weights), Bias: bias, Examples: len(feedback)}
+	right := 0
+	for _, f := range feedback {
+		if (model.Probability(f.Features) >= 0.5) == f.Accepted {
+			right++
+		}
+	}
+	model.Accuracy = float64(right) / examples
+	return model, nil
+}
+
+func label(accepted bool) float64 {
+	if accepted {
+		return 1
+	}
+	return 0
+}
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+func dot(a []float64, b []float64) float64 {
+	result := 0.0
+	for i := range a {
+		result += a[i] * b[i]
+	}
+	return result
+}
+
+func (w ScoreWeights) values() []float64 {
+	return []float64{w.Industry, w.JobTitle, w.Seniority, w.Gender, w.Education, w.Relevance}
+}
+
+func scoreWeightsOf(values []float64) ScoreWeights {
+	return ScoreWeights{
+		Industry:  values[0],
+		JobTitle:  values[1],
+		Seniority: values[2],
+		Gender:    values[3],
+		Education: values[4],
+		Relevance: values[5],
+	}
+}
+
+func (b ScoreBreakdown) values() []float64 {
+	return []float64{b.Industry, b.JobTitle, b.Seniority, b.Gender, b.Education, b.Relevance}
+}
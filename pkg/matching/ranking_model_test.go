@@ -0,0 +1,56 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankingModel(t *testing.T) {
+	feedback := []Feedback{
+		{Accepted: true, Features: ScoreBreakdown{JobTitle: 1, Industry: 0}},
+		{Accepted: true, Features: ScoreBreakdown{JobTitle: 1, Industry: 1}},
+		{Accepted: true, Features: ScoreBreakdown{JobTitle: 0.8, Industry: 0.5}},
+		{Accepted: false, Features: ScoreBreakdown{JobTitle: 0, Industry: 1}},
+		{Accepted: false, Features: ScoreBreakdown{JobTitle: 0, Industry: 0.5, Gender: 1}},
+		{Accepted: false, Features: ScoreBreakdown{JobTitle: 0.2, Industry: 0}},
+	}
+
+	t.Run("Given feedback accepting participants by job title, When the model is trained, Then job title must weigh more than industry", func(t *testing.T) {
+		model, err := TrainRankingModel(feedback, DefaultTrainingOptions)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 6, model.Examples)
+		assert.Equal(t, 1.0, model.Accuracy)
+		assert.Greater(t, model.Weights.JobTitle, model.Weights.Industry)
+		assert.Greater(t, model.Probability(ScoreBreakdown{JobTitle: 1}), 0.5)
+		assert.Less(t, model.Probability(ScoreBreakdown{Industry: 1}), 0.5)
+	})
+	t.Run("Given the same feedback, When the model is trained twice, Then must return the same model", func(t *testing.T) {
+		first, _ := TrainRankingModel(feedback, DefaultTrainingOptions)
+		second, _ := TrainRankingModel(feedback, DefaultTrainingOptions)
+
+		assert.Equal(t, first, second)
+	})
+	t.Run("Given feedback only accepting participants, When the model is trained, Then must return not enough feedback", func(t *testing.T) {
+		_, err := TrainRankingModel(feedback[:3], DefaultTrainingOptions)
+
+		assert.Equal(t, ErrNotEnoughFeedback, err)
+	})
+	t.Run("Given a model with negative weights, When score weights are taken, Then negative weights must not add to the score", func(t *testing.T) {
+		model := RankingModel{Weights: ScoreWeights{JobTitle: 2, Industry: -1}}
+
+		assert.Equal(t, ScoreWeights{JobTitle: 2}, model.ScoreWeights())
+		assert.Equal(t, DefaultScoreWeights, RankingModel{Weights: ScoreWeights{Gender: -1}}.ScoreWeights())
+	})
+	t.Run("Given a score service with a trained model, When participants are scored, Then must rank them by the learned weights", func(t *testing.T) {
+		model, _ := TrainRankingModel(feedback, DefaultTrainingOptions)
+		service := NewScoreService(WithRankingModel(model))
+		project := Project{ProfessionalIndustry: []string{"Banking"}, ProfessionalJobTitles: []string{"Java Developer"}}
+		developer := Participant{JobTitle: "Java Developer", Industry: []string{"Retail"}}
+		banker := Participant{JobTitle: "Teller", Industry: []string{"Banking"}}
+
+		assert.Greater(t, service.GetMatchingScore(project, developer), service.GetMatchingScore(project, banker))
+		assert.InDelta(t, 1.0, service.GetMatchingScore(project, Participant{JobTitle: "Senior Java Developer", Industry: []string{"Banking"}}), 0.0001)
+	})
+}
@@ -0,0 +1,115 @@
+package matching
+
+import (
+	"strings"
+	"sync"
+)
+
+//relevanceCacheSize is the amount of project queries whose relevance is kept between requests
+const relevanceCacheSize = 128
+
+//ParticipantIndex is implemented by repositories that keep an InvertedIndex of their participants
+type ParticipantIndex interface {
+	//Index returns the InvertedIndex of the participants, or nil while they are loading
+	Index() *InvertedIndex
+}
+
+//queryStopWords are words of project names that don't describe the participants looked for
+var queryStopWords = map[string]bool{
+	"a":           true,
+	"an":          true,
+	"with":        true,
+	"looking":     true,
+	"seeking":     true,
+	"searching":   true,
+	"experienced": true,
+	"experience":  true,
+	"who":         true,
+	"are":         true,
+	"to":          true,
+	"on":          true,
+	"or":          true,
+	"at":          true,
+	"we":          true,
+	"people":      true,
+	"participant": true,
+}
+
+//relevanceTerms returns the unique terms of the project job titles, industries and name,
+//in the order they are found
+func relevanceTerms(project Project) []string {
+	text := strings.Join(project.ProfessionalJobTitles, " ") + " " +
+		strings.Join(project.ProfessionalIndustry, " ") + " " + project.Name
+	terms := []string{}
+	seen := map[string]bool{}
+	for _, term := range textTerms(text) {
+		if !seen[term] && !queryStopWords[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+//relevanceScorer scores participants by the BM25 of the project terms, scaled by the most relevant participant
+//so they go from 0 to 1. The scores of a query are kept until the index changes, so they are computed once
+//for every project instead of for every participant
+type relevanceScorer struct {
+	participants ParticipantIndex
+
+	mu     sync.Mutex
+	index  *InvertedIndex
+	scores map[string]map[string]float64
+}
+
+func newRelevanceScorer(participants ParticipantIndex) *relevanceScorer {
+	return &relevanceScorer{participants: participants}
+}
+
+//projectScores returns the relevance of every participant for the project by its ID, or nil when the participants
+//aren't indexed or no participant has a term of the project
+func (r *relevanceScorer) projectScores(project Project) map[string]float64 {
+	if r == nil {
+		return nil
+	}
+	terms := relevanceTerms(project)
+	if len(terms) == 0 {
+		return nil
+	}
+	index := r.participants.Index()
+	if index == nil {
+		return nil
+	}
+	scores := r.queryScores(index, terms)
+	if len(scores) == 0 {
+		return nil
+	}
+	return scores
+}
+
+func (r *relevanceScorer) queryScores(index *InvertedIndex, terms []string) map[string]float64 {
+	key := strings.Join(terms, " ")
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.index != index || len(r.scores) >= relevanceCacheSize {
+		r.index = index
+		r.scores = map[string]map[string]float64{}
+	}
+	if scores, ok := r.scores[key]; ok {
+		return scores
+	}
+
+	scores := index.BM25(terms)
+	max := 0.0
+	for _, score := range scores {
+		if score > max {
+			max = score
+		}
+	}
+	for participant, score := range scores {
+		scores[participant] = score / max
+	}
+	r.scores[key] = scores
+	return scores
+}
@@ -1,6 +1,19 @@
 package matching
 
+import "errors"
+
+//ErrParticipantsLoading is retrived when the repository is still loading its participants
+var ErrParticipantsLoading = errors.New("Participants are still loading")
+
+//ErrParticipantNotFound is retrived when there is no participant with the given ID
+var ErrParticipantNotFound = errors.New("Participant not found")
+
 //ParticipantRepository is an interface where can access to Participants for projects
 type ParticipantRepository interface {
 	GetByFormattedAddress(address string) ([]Participant, error)
 }
+
+//ParticipantFinder is an interface where can access to a Participant by its ID
+type ParticipantFinder interface {
+	GetByID(id string) (Participant, error)
+}
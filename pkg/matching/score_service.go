@@ -1,67 +1,332 @@
 package matching
 
 import (
+	"math"
 	"strings"
 )
 
-var highSeniorityIndicators = []string{
-	"Sr ",
-	"Sr. ",
-	"Senior ",
-	"Expert ",
-	"Principal ",
-	"Staff ",
-	"Specialist ",
-	"Head ",
-}
+//seniorityDecay is the seniority score lost for every level a participant is out of the project seniority range
+const seniorityDecay = 0.25
 
 //ScoreService manage all relative calculation to matching score
 type ScoreService interface {
 	GetMatchingScore(project Project, participant Participant) float64
+	GetMatchingScoreBreakdown(project Project, participant Participant) ScoreBreakdown
+	//GetMatchingFeatures returns the score of every criteria from 0 to 1, before it's weighted
+	GetMatchingFeatures(project Project, participant Participant) ScoreBreakdown
+	//MeetsRequirements returns true when the participant meets every criteria required by the project
+	MeetsRequirements(project Project, participant Participant) bool
+	//ForProject returns the ProjectScore of the project, to score many participants for it
+	ForProject(project Project) ProjectScore
+}
+
+//ProjectScore scores participants for a single project, computing what only depends on the project once
+type ProjectScore interface {
+	GetMatchingScoreBreakdown(participant Participant) ScoreBreakdown
+	GetMatchingFeatures(participant Participant) ScoreBreakdown
+}
+
+//ScoreWeights represents how much every criteria contributes to a matching score
+type ScoreWeights struct {
+	Industry  float64 `json:"industry"`
+	JobTitle  float64 `json:"jobTitle"`
+	Seniority float64 `json:"seniority"`
+	Gender    float64 `json:"gender"`
+	Education float64 `json:"education"`
+	Relevance float64 `json:"relevance"`
+}
+
+//DefaultScoreWeights are the ScoreWeights used when no other ones are given
+var DefaultScoreWeights = ScoreWeights{Industry: 0.4, JobTitle: 0.4, Seniority: 0.2, Gender: 0.2, Education: 0.2, Relevance: 0.2}
+
+func (w ScoreWeights) total() float64 {
+	return w.Industry + w.JobTitle + w.Seniority + w.Gender + w.Education + w.Relevance
+}
+
+//ScoreBreakdown represents the contribution of every criteria to a matching score.
+//Every criteria is scored from 0 to 1 and multiplied by its weight, so the score goes from 0 to 1
+type ScoreBreakdown struct {
+	Industry  float64 `json:"industry"`
+	JobTitle  float64 `json:"jobTitle"`
+	Seniority float64 `json:"seniority"`
+	Gender    float64 `json:"gender"`
+	Education float64 `json:"education"`
+	//Relevance is the BM25 relevance of the participant profile for the project terms
+	Relevance float64 `json:"relevance"`
+}
+
+//Total returns the matching score composed by the breakdown
+func (b ScoreBreakdown) Total() float64 {
+	return b.Industry + b.JobTitle + b.Seniority + b.Gender + b.Education + b.Relevance
 }
 
 type scoreService struct {
+	titles     TitleMatcher
+	taxonomy   *JobTitleTaxonomy
+	industries *IndustryTaxonomy
+	weights    ScoreWeights
+	relevance  *relevanceScorer
+}
+
+//ScoreOption customizes a ScoreService built by NewScoreService
+type ScoreOption func(s *scoreService)
+
+//WithIndustryTaxonomy changes the IndustryTaxonomy used to give credit to related industries,
+//nil only scores industries with the same name
+func WithIndustryTaxonomy(taxonomy *IndustryTaxonomy) ScoreOption {
+	return func(s *scoreService) {
+		s.industries = taxonomy
+	}
+}
+
+//WithScoreWeights changes the weight of every criteria in the matching score
+func WithScoreWeights(weights ScoreWeights) ScoreOption {
+	return func(s *scoreService) {
+		s.weights = weights
+	}
+}
+
+//WithRelevanceIndex ranks participants by the BM25 relevance of their job title and industries for the
+//project job titles, industries and name, using the InvertedIndex of the participants
+func WithRelevanceIndex(index ParticipantIndex) ScoreOption {
+	return func(s *scoreService) {
+		s.relevance = newRelevanceScorer(index)
+	}
+}
+
+//WithRankingModel weights every criteria with the weights learned by the RankingModel, instead of the default ones
+func WithRankingModel(model RankingModel) ScoreOption {
+	return func(s *scoreService) {
+		s.weights = model.ScoreWeights()
+	}
+}
+
+//WithJobTitleTaxonomy changes the JobTitleTaxonomy used to match job titles by role, nil disables it
+func WithJobTitleTaxonomy(taxonomy *JobTitleTaxonomy) ScoreOption {
+	return func(s *scoreService) {
+		s.taxonomy = taxonomy
+	}
 }
 
 func (s *scoreService) GetMatchingScore(project Project, participant Participant) float64 {
-	score := evalIndustriesScore(participant.Industry, project.ProfessionalIndustry)
-	score += evalJobTitleScore(participant.JobTitle, project.ProfessionalJobTitles)
+	return s.GetMatchingScoreBreakdown(project, participant).Total()
+}
 
-	return score
+func (s *scoreService) GetMatchingScoreBreakdown(project Project, participant Participant) ScoreBreakdown {
+	return s.ForProject(project).GetMatchingScoreBreakdown(participant)
 }
 
-func evalIndustriesScore(participantIndustries []string, projectIndustries []string) float64 {
+func (s *scoreService) GetMatchingFeatures(project Project, participant Participant) ScoreBreakdown {
+	return s.ForProject(project).GetMatchingFeatures(participant)
+}
+
+//ForProject computes the relevance of the participants and the weights of the project criteria once
+func (s *scoreService) ForProject(project Project) ProjectScore {
+	relevance := s.relevance.projectScores(project)
+	return &projectScore{
+		service:   s,
+		project:   project,
+		weights:   s.projectWeights(project, relevance != nil),
+		relevance: relevance,
+	}
+}
+
+type projectScore struct {
+	service   *scoreService
+	project   Project
+	weights   ScoreWeights
+	relevance map[string]float64
+}
+
+func (p *projectScore) GetMatchingScoreBreakdown(participant Participant) ScoreBreakdown {
+	features := p.GetMatchingFeatures(participant)
+
+	return ScoreBreakdown{
+		Industry:  p.weights.Industry * features.Industry,
+		JobTitle:  p.weights.JobTitle * features.JobTitle,
+		Seniority: p.weights.Seniority * features.Seniority,
+		Gender:    p.weights.Gender * features.Gender,
+		Education: p.weights.Education * features.Education,
+		Relevance: p.weights.Relevance * features.Relevance,
+	}
+}
+
+func (p *projectScore) GetMatchingFeatures(participant Participant) ScoreBreakdown {
+	s, project := p.service, p.project
+	return ScoreBreakdown{
+		Industry:  s.evalIndustriesScore(participant.Industry, project.ProfessionalIndustry),
+		JobTitle:  s.evalJobTitleScore(participant.JobTitle, project.ProfessionalJobTitles, project.jobTitleThreshold()),
+		Seniority: s.evalSeniorityScore(participant.seniority(), project.Seniority),
+		Gender:    evalMembershipScore(project.genders(), participant.Gender),
+		Education: evalMembershipScore(project.Education, participant.Education),
+		Relevance: p.relevance[participant.ID],
+	}
+}
+
+//MeetsRequirements returns true when the participant has one of the project industries, or an industry of its
+//groups, a job title reaching the threshold, a seniority in the range, and one of the genders and education levels,
+//for every one of these criteria the project requires
+func (s *scoreService) MeetsRequirements(project Project, participant Participant) bool {
+	for _, criterion := range project.Required {
+		if !s.meets(criterion, project, participant) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *scoreService) meets(criterion Criterion, project Project, participant Participant) bool {
+	switch criterion {
+	case CriterionIndustry:
+		for _, expected := range project.ProfessionalIndustry {
+			for _, industry := range participant.Industry {
+				if s.industryCovers(expected, industry) {
+					return true
+				}
+			}
+		}
+		return false
+	case CriterionJobTitle:
+		threshold := project.jobTitleThreshold()
+		return s.evalJobTitleScore(participant.JobTitle, project.ProfessionalJobTitles, threshold) >= threshold
+	case CriterionSeniority:
+		return project.Seniority != nil && project.Seniority.Contains(participant.seniority())
+	case CriterionGender:
+		return containsFold(project.genders(), participant.Gender)
+	case CriterionEducation:
+		return containsFold(project.Education, participant.Education)
+	}
+	return true
+}
+
+//projectWeights returns the weights of the criteria the project prefers, scaled to add up to 1, so a participant
+//matching everything a project prefers scores 1 whatever the project asks for. Criteria the project doesn't ask for,
+//or requires, don't add to the score, and relevance only adds when the participants are ranked by relevance
+func (s *scoreService) projectWeights(project Project, relevant bool) ScoreWeights {
+	weights := s.weights
+	if !project.asks(CriterionIndustry) || project.requires(CriterionIndustry) {
+		weights.Industry = 0
+	}
+	if !project.asks(CriterionJobTitle) || project.requires(CriterionJobTitle) {
+		weights.JobTitle = 0
+	}
+	if !project.asks(CriterionSeniority) || project.requires(CriterionSeniority) {
+		weights.Seniority = 0
+	}
+	if !project.asks(CriterionGender) || project.requires(CriterionGender) {
+		weights.Gender = 0
+	}
+	if !project.asks(CriterionEducation) || project.requires(CriterionEducation) {
+		weights.Education = 0
+	}
+	if !relevant {
+		weights.Relevance = 0
+	}
+
+	total := weights.total()
+	if total == 0 {
+		return weights
+	}
+	return ScoreWeights{
+		Industry:  weights.Industry / total,
+		JobTitle:  weights.JobTitle / total,
+		Seniority: weights.Seniority / total,
+		Gender:    weights.Gender / total,
+		Education: weights.Education / total,
+		Relevance: weights.Relevance / total,
+	}
+}
+
+//evalMembershipScore returns 1 when the value is one of the expected values, ignoring case, and 0 otherwise
+func evalMembershipScore(expected []string, value string) float64 {
+	if containsFold(expected, value) {
+		return 1
+	}
+	return 0
+}
+
+//evalIndustriesScore returns the share of the project industries covered by the participant industries, from 0 to 1.
+//Every project industry is covered by its best similarity with the participant industries, so listing more
+//industries than the project asks for doesn't add anything
+func (s *scoreService) evalIndustriesScore(participantIndustries []string, projectIndustries []string) float64 {
+	if len(projectIndustries) == 0 {
+		return 0
+	}
+
 	score := 0.0
-	for _, industry := range participantIndustries {
-		for _, projectIndustry := range projectIndustries {
-			if strings.ToLower(industry) == strings.ToLower(projectIndustry) {
-				score++
+	for _, projectIndustry := range projectIndustries {
+		best := 0.0
+		for _, industry := range participantIndustries {
+			if similarity := s.industrySimilarity(projectIndustry, industry); similarity > best {
+				best = similarity
 			}
 		}
+		score += best
 	}
 
-	return score
+	return math.Min(1, score/float64(len(projectIndustries)))
+}
 
+func (s *scoreService) industryCovers(expected string, actual string) bool {
+	if s.industries != nil {
+		return s.industries.Covers(expected, actual)
+	}
+	return strings.ToLower(expected) == strings.ToLower(actual)
 }
 
-func evalJobTitleScore(participantJobTitle string, projectExpectedJobsTitles []string) float64 {
+func (s *scoreService) industrySimilarity(expected string, actual string) float64 {
+	if s.industries != nil {
+		return s.industries.Similarity(expected, actual)
+	}
+	if strings.ToLower(expected) == strings.ToLower(actual) {
+		return 1
+	}
+	return 0
+}
+
+//evalJobTitleScore returns the best similarity of the participant job title with the expected job titles.
+//Titles below the threshold only score when the taxonomy resolves them to the same role, or to a role of the
+//same family. The best match is taken instead of adding every match, so a project listing synonyms of a
+//role doesn't score them several times
+func (s *scoreService) evalJobTitleScore(participantJobTitle string, projectExpectedJobsTitles []string, threshold float64) float64 {
 	score := 0.0
-	loweredParticipantJobTitle := strings.ToLower(participantJobTitle)
 	for _, jobTitle := range projectExpectedJobsTitles {
-		loweredJobTitle := strings.ToLower(jobTitle)
-		if strings.Contains(loweredParticipantJobTitle, loweredJobTitle) {
-			score++
-			for _, seniority := range highSeniorityIndicators {
-				if strings.Contains(loweredParticipantJobTitle, strings.ToLower(seniority)) {
-					score += 0.5
-				}
+		similarity := s.titles.Similarity(jobTitle, participantJobTitle)
+		if similarity < threshold {
+			similarity = 0
+		}
+		if s.taxonomy != nil {
+			if roleSimilarity := s.taxonomy.Similarity(jobTitle, participantJobTitle); roleSimilarity > similarity {
+				similarity = roleSimilarity
 			}
 		}
+		if similarity > score {
+			score = similarity
+		}
 	}
 	return score
 }
 
-//NewScoreService returns a new ScoreService
-func NewScoreService() ScoreService {
-	return &scoreService{}
+//evalSeniorityScore returns 1 when the seniority is in the project range, and seniorityDecay less for every level
+//it's out of the range. Without a range every seniority scores 0, and it isn't weighted
+func (s *scoreService) evalSeniorityScore(seniority Seniority, expected *SeniorityRange) float64 {
+	if expected == nil || seniority == SeniorityUnknown {
+		return 0
+	}
+	return math.Max(0, 1-seniorityDecay*float64(expected.distance(seniority)))
+}
+
+//NewScoreService returns a new ScoreService that matches job titles with the DefaultJobTitleTaxonomy
+//and industries with the DefaultIndustryTaxonomy, weighting them by DefaultScoreWeights
+func NewScoreService(options ...ScoreOption) ScoreService {
+	s := &scoreService{
+		weights:    DefaultScoreWeights,
+		titles:     NewTitleMatcher(),
+		taxonomy:   DefaultJobTitleTaxonomy(),
+		industries: DefaultIndustryTaxonomy(),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
 }
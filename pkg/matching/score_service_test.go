@@ -7,8 +7,8 @@ import (
 )
 
 func TestScoreService(t *testing.T) {
-	t.Run("Given a project with a professional industries, When matching score is evaluated, Then must recieve one point for every industry match", func(t *testing.T) {
-		service := NewScoreService()
+	t.Run("Given a project with a professional industries and no industry taxonomy, When matching score is evaluated, Then must score the share of project industries matched", func(t *testing.T) {
+		service := NewScoreService(WithIndustryTaxonomy(nil))
 		project := Project{
 			ProfessionalIndustry: []string{
 				"Banking",
@@ -33,9 +33,9 @@ func TestScoreService(t *testing.T) {
 
 		score := service.GetMatchingScore(project, participant)
 
-		assert.Equal(t, 3.0, score)
+		assert.Equal(t, 3.0/8, score)
 	})
-	t.Run("Given a project with JobTitles, When matching score is evaluated, Then add one point to score if the participant job title is a full match with project expected job titles", func(t *testing.T) {
+	t.Run("Given a project with JobTitles, When matching score is evaluated, Then the job title must score 1 if the participant job title is a full match with project expected job titles", func(t *testing.T) {
 		service := NewScoreService()
 		project := Project{
 			ProfessionalJobTitles: []string{
@@ -58,10 +58,10 @@ func TestScoreService(t *testing.T) {
 
 		score := service.GetMatchingScore(project, participant)
 
-		assert.Equal(t, 1.0, score)
+		assert.InDelta(t, 1.0, score, 0.001)
 	})
 
-	t.Run("Given a project with JobTitles, When matching score is evaluated, Then add half point to score if the participant job title is a full match with project expected job titles and contains a high seniority indicatior", func(t *testing.T) {
+	t.Run("Given a project with JobTitles and no seniority range, When matching score is evaluated, Then must score 1 if the participant job title is a full match with project expected job titles and contains a high seniority indicatior, like without it", func(t *testing.T) {
 		service := NewScoreService()
 		project := Project{
 			ProfessionalJobTitles: []string{
@@ -84,7 +84,264 @@ func TestScoreService(t *testing.T) {
 
 		score := service.GetMatchingScore(project, participant)
 
-		assert.Equal(t, 1.5, score)
+		assert.InDelta(t, 1.0, score, 0.001)
+	})
+	t.Run("Given a project with industries and JobTitles, When matching score breakdown is evaluated, Then every criteria must be reported and add up to the matching score", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			ProfessionalIndustry:  []string{"Banking", "Computer Software"},
+			ProfessionalJobTitles: []string{"Software Engineer"},
+			Seniority:             &SeniorityRange{Min: SenioritySenior},
+		}
+		participant := Participant{
+			Industry: []string{"Banking"},
+			JobTitle: "Senior Software Engineer",
+		}
+
+		breakdown := service.GetMatchingScoreBreakdown(project, participant)
+
+		assert.InDelta(t, 0.5*DefaultScoreWeights.Industry, breakdown.Industry, 0.001)
+		assert.InDelta(t, DefaultScoreWeights.JobTitle, breakdown.JobTitle, 0.001)
+		assert.InDelta(t, DefaultScoreWeights.Seniority, breakdown.Seniority, 0.001)
+		assert.Equal(t, service.GetMatchingScore(project, participant), breakdown.Total())
+	})
+	t.Run("Given a participant job title with a typo and no taxonomy, When matching score is evaluated, Then must add partial points by similarity", func(t *testing.T) {
+		service := NewScoreService(WithJobTitleTaxonomy(nil))
+		project := Project{
+			ProfessionalJobTitles: []string{"Software Engineer"},
+		}
+		participant := Participant{
+			JobTitle: "Sr Sofware Engineer",
+		}
+
+		breakdown := service.GetMatchingScoreBreakdown(project, participant)
+
+		assert.InDelta(t, 0.94, breakdown.JobTitle, 0.01)
+		assert.Equal(t, 0.0, breakdown.Seniority)
+	})
+	t.Run("Given a project with a job title threshold and no taxonomy, When a participant title is below it, Then must not add points", func(t *testing.T) {
+		service := NewScoreService(WithJobTitleTaxonomy(nil))
+		participant := Participant{
+			JobTitle: "Software Engineer",
+		}
+
+		strict := service.GetMatchingScore(Project{ProfessionalJobTitles: []string{"Java Software Engineer"}}, participant)
+		lenient := service.GetMatchingScore(Project{ProfessionalJobTitles: []string{"Java Software Engineer"}, JobTitleThreshold: 0.5}, participant)
+
+		assert.Equal(t, 0.0, strict)
+		assert.InDelta(t, 0.67, lenient, 0.01)
+	})
+	t.Run("Given a participant job title that is a synonym of an expected title, When matching score is evaluated, Then must score as a full match", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			ProfessionalJobTitles: []string{"Software Engineer"},
+		}
+		participant := Participant{
+			JobTitle: "Senior SWE",
+		}
+
+		breakdown := service.GetMatchingScoreBreakdown(project, participant)
+
+		assert.Equal(t, 0.0, breakdown.Industry)
+		assert.InDelta(t, 1.0, breakdown.JobTitle, 0.001)
+		assert.Equal(t, 0.0, breakdown.Seniority)
+	})
+	t.Run("Given a participant job title of the same role family, When matching score is evaluated, Then must add the family credit", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			ProfessionalJobTitles: []string{"Software Engineer"},
+		}
+		participant := Participant{
+			JobTitle: "Java/J2EE Developer",
+		}
+
+		score := service.GetMatchingScore(project, participant)
+
+		assert.InDelta(t, DefaultFamilyCredit, score, 0.001)
+	})
+	t.Run("Given a project listing several synonyms of a role, When matching score is evaluated, Then must score the role once", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			ProfessionalJobTitles: []string{"Developer", "Software Engineer", "Programmer", "SDE"},
+		}
+		participant := Participant{
+			JobTitle: "Software Developer",
+		}
+
+		score := service.GetMatchingScore(project, participant)
+
+		assert.InDelta(t, 1.0, score, 0.001)
+	})
+	t.Run("Given a project with a professional industries, When matching score is evaluated, Then must add partial points for industries of the same group", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			ProfessionalIndustry: []string{"Computer Software", "Insurance"},
+		}
+		participant := Participant{
+			Industry: []string{"Information Technology and Services", "Banking"},
+		}
+
+		score := service.GetMatchingScore(project, participant)
+
+		assert.Equal(t, DefaultSiblingCredit, score)
 	})
+	t.Run("Given a project with an industry group, When matching score is evaluated, Then must add the parent credit for industries of the group", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			ProfessionalIndustry: []string{"Technology"},
+		}
+		participant := Participant{
+			Industry: []string{"Computer Games"},
+		}
 
+		score := service.GetMatchingScore(project, participant)
+
+		assert.Equal(t, DefaultParentCredit, score)
+	})
+	t.Run("Given a specialist and a participant listing many industries, When matching score is evaluated, Then the extra industries must not add points", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			ProfessionalIndustry: []string{"Banking"},
+		}
+		specialist := Participant{
+			Industry: []string{"Banking"},
+		}
+		generalist := Participant{
+			Industry: []string{"Banking", "Insurance", "Financial Services", "Capital Markets", "Accounting", "Retail", "Internet", "Automotive"},
+		}
+
+		assert.Equal(t, 1.0, service.GetMatchingScore(project, specialist))
+		assert.Equal(t, service.GetMatchingScore(project, specialist), service.GetMatchingScore(project, generalist))
+	})
+	t.Run("Given projects with lists of different lengths, When a participant matches all of them, Then must score 1 in every project", func(t *testing.T) {
+		service := NewScoreService()
+		participant := Participant{
+			Industry: []string{"Banking", "Computer Software", "Insurance"},
+			JobTitle: "Senior Software Engineer",
+		}
+		short := Project{
+			ProfessionalIndustry:  []string{"Banking"},
+			ProfessionalJobTitles: []string{"Software Engineer"},
+		}
+		long := Project{
+			ProfessionalIndustry:  []string{"Banking", "Computer Software", "Insurance"},
+			ProfessionalJobTitles: []string{"Software Engineer", "Developer", "Programmer", "Java Developer"},
+		}
+
+		assert.InDelta(t, 1.0, service.GetMatchingScore(short, participant), 0.001)
+		assert.InDelta(t, 1.0, service.GetMatchingScore(long, participant), 0.001)
+	})
+	t.Run("Given score weights, When matching score breakdown is evaluated, Then every criteria must contribute by its weight", func(t *testing.T) {
+		service := NewScoreService(WithScoreWeights(ScoreWeights{Industry: 3, JobTitle: 1, Seniority: 0}))
+		project := Project{
+			ProfessionalIndustry:  []string{"Banking"},
+			ProfessionalJobTitles: []string{"Software Engineer"},
+		}
+		participant := Participant{
+			Industry: []string{"Banking"},
+			JobTitle: "Senior Software Engineer",
+		}
+
+		breakdown := service.GetMatchingScoreBreakdown(project, participant)
+
+		assert.Equal(t, ScoreBreakdown{Industry: 0.75, JobTitle: 0.25, Seniority: 0}, breakdown)
+	})
+	t.Run("Given a project with a seniority range, When matching score is evaluated, Then participants in the range must score 1 and the rest less for every level out of it", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			Seniority: &SeniorityRange{Min: SeniorityMid, Max: SenioritySenior},
+		}
+
+		assert.Equal(t, 1.0, service.GetMatchingScore(project, Participant{JobTitle: "Software Engineer"}))
+		assert.Equal(t, 1.0, service.GetMatchingScore(project, Participant{JobTitle: "Sr. Data Analyst"}))
+		assert.Equal(t, 0.75, service.GetMatchingScore(project, Participant{JobTitle: "Junior Developer"}))
+		assert.Equal(t, 0.5, service.GetMatchingScore(project, Participant{JobTitle: "Intern"}))
+		assert.Equal(t, 0.0, service.GetMatchingScore(project, Participant{JobTitle: "CEO"}))
+	})
+	t.Run("Given a participant with a seniority, When matching score is evaluated, Then must use it instead of parsing the job title", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			Seniority: &SeniorityRange{Min: SeniorityDirector},
+		}
+		participant := Participant{
+			JobTitle:  "Software Engineer",
+			Seniority: SeniorityDirector,
+		}
+
+		score := service.GetMatchingScore(project, participant)
+
+		assert.Equal(t, 1.0, score)
+	})
+	t.Run("Given a project with genders and education, When matching score breakdown is evaluated, Then participants with one of them must score for it", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			Genders:   "Female, Non-binary",
+			Education: []string{"Master's Degree"},
+		}
+		participant := Participant{
+			Gender:    "female",
+			Education: "Bachelor's Degree",
+		}
+
+		breakdown := service.GetMatchingScoreBreakdown(project, participant)
+
+		assert.Equal(t, ScoreBreakdown{Gender: 0.5, Education: 0}, breakdown)
+	})
+	t.Run("Given a project with N/A genders, When matching score is evaluated, Then gender must not be scored", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			Genders:              "N/A",
+			ProfessionalIndustry: []string{"Banking"},
+		}
+
+		breakdown := service.GetMatchingScoreBreakdown(project, Participant{Gender: "male", Industry: []string{"Banking"}})
+
+		assert.Equal(t, ScoreBreakdown{Industry: 1}, breakdown)
+	})
+	t.Run("Given a project with required criteria, When matching score is evaluated, Then only preferred criteria must add to the score", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			ProfessionalIndustry:  []string{"Banking"},
+			ProfessionalJobTitles: []string{"Software Engineer"},
+			Required:              []Criterion{CriterionJobTitle},
+		}
+		participant := Participant{
+			Industry: []string{"Banking"},
+			JobTitle: "Software Engineer",
+		}
+
+		breakdown := service.GetMatchingScoreBreakdown(project, participant)
+
+		assert.Equal(t, 0.0, breakdown.JobTitle)
+		assert.InDelta(t, 1.0, breakdown.Industry, 0.001)
+	})
+	t.Run("Given a project with required criteria, When requirements are checked, Then participants must meet every one of them", func(t *testing.T) {
+		service := NewScoreService()
+		project := Project{
+			ProfessionalIndustry:  []string{"Finance"},
+			ProfessionalJobTitles: []string{"Software Engineer"},
+			Genders:               "female",
+			Education:             []string{"Bachelor's Degree"},
+			Seniority:             &SeniorityRange{Min: SenioritySenior},
+			Required:              Criteria,
+		}
+		participant := Participant{
+			Industry:  []string{"Investment Banking"},
+			JobTitle:  "Senior SWE",
+			Gender:    "Female",
+			Education: "bachelor's degree",
+		}
+		sameFamily := participant
+		sameFamily.JobTitle = "Senior Java Developer"
+		otherGender := participant
+		otherGender.Gender = "male"
+		otherIndustry := participant
+		otherIndustry.Industry = []string{"Retail"}
+
+		assert.True(t, service.MeetsRequirements(project, participant))
+		assert.False(t, service.MeetsRequirements(project, sameFamily))
+		assert.False(t, service.MeetsRequirements(project, otherGender))
+		assert.False(t, service.MeetsRequirements(project, otherIndustry))
+		assert.True(t, service.MeetsRequirements(Project{}, Participant{}))
+	})
 }
@@ -0,0 +1,175 @@
+package matching
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+//Seniority represents the level of a participant in its career, from intern to executive
+type Seniority int
+
+const (
+	//SeniorityUnknown is the Seniority of participants without a job title
+	SeniorityUnknown Seniority = iota
+	SeniorityIntern
+	SeniorityJunior
+	SeniorityMid
+	SenioritySenior
+	//SeniorityStaff is the level of staff, principal and distinguished individual contributors
+	SeniorityStaff
+	SeniorityLead
+	SeniorityManager
+	SeniorityDirector
+	SeniorityExecutive
+)
+
+var seniorityNames = []string{
+	"unknown",
+	"intern",
+	"junior",
+	"mid",
+	"senior",
+	"staff",
+	"lead",
+	"manager",
+	"director",
+	"executive",
+}
+
+//seniorityWords are the words of a job title that tell its Seniority
+var seniorityWords = map[string]Seniority{
+	"intern":        SeniorityIntern,
+	"internship":    SeniorityIntern,
+	"trainee":       SeniorityIntern,
+	"apprentice":    SeniorityIntern,
+	"junior":        SeniorityJunior,
+	"jr":            SeniorityJunior,
+	"entry":         SeniorityJunior,
+	"graduate":      SeniorityJunior,
+	"mid":           SeniorityMid,
+	"intermediate":  SeniorityMid,
+	"ii":            SeniorityMid,
+	"senior":        SenioritySenior,
+	"sr":            SenioritySenior,
+	"expert":        SenioritySenior,
+	"iii":           SenioritySenior,
+	"staff":         SeniorityStaff,
+	"principal":     SeniorityStaff,
+	"distinguished": SeniorityStaff,
+	"lead":          SeniorityLead,
+	"leader":        SeniorityLead,
+	"supervisor":    SeniorityLead,
+	"manager":       SeniorityManager,
+	"mgr":           SeniorityManager,
+	"director":      SeniorityDirector,
+	"head":          SeniorityDirector,
+	"vp":            SeniorityExecutive,
+	"svp":           SeniorityExecutive,
+	"evp":           SeniorityExecutive,
+	"president":     SeniorityExecutive,
+	"chief":         SeniorityExecutive,
+	"ceo":           SeniorityExecutive,
+	"cto":           SeniorityExecutive,
+	"cfo":           SeniorityExecutive,
+	"coo":           SeniorityExecutive,
+	"cmo":           SeniorityExecutive,
+	"cio":           SeniorityExecutive,
+	"founder":       SeniorityExecutive,
+}
+
+//seniorityPhrases are the pairs of words that tell a Seniority when their words alone don't, because they are
+//common in other titles, like "Product Owner" or "Research Fellow"
+var seniorityPhrases = map[string]Seniority{
+	"business owner":   SeniorityExecutive,
+	"company owner":    SeniorityExecutive,
+	"technical fellow": SeniorityStaff,
+}
+
+func (s Seniority) String() string {
+	if s < SeniorityUnknown || int(s) >= len(seniorityNames) {
+		return seniorityNames[SeniorityUnknown]
+	}
+	return seniorityNames[s]
+}
+
+//MarshalText writes the Seniority by its name, like "senior"
+func (s Seniority) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+//UnmarshalText reads a Seniority by its name, like "senior"
+func (s *Seniority) UnmarshalText(text []byte) error {
+	seniority, err := ParseSeniority(string(text))
+	if err != nil {
+		return err
+	}
+	*s = seniority
+	return nil
+}
+
+//ParseSeniority returns the Seniority with the given name, like "senior". An empty name is SeniorityUnknown
+func ParseSeniority(name string) (Seniority, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return SeniorityUnknown, nil
+	}
+	for i, seniorityName := range seniorityNames {
+		if name == seniorityName {
+			return Seniority(i), nil
+		}
+	}
+	return SeniorityUnknown, fmt.Errorf("unknown seniority %q, must be one of %s", name, strings.Join(seniorityNames[1:], ", "))
+}
+
+//ParseSeniorityLevel returns the Seniority told by a job title. When the title has several seniority words the
+//highest level wins, so "Senior Engineering Manager" is a manager. Titles without seniority words, like
+//"Software Engineer", are mid level, and empty titles are SeniorityUnknown
+func ParseSeniorityLevel(jobTitle string) Seniority {
+	words := strings.FieldsFunc(strings.ToLower(jobTitle), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return SeniorityUnknown
+	}
+
+	seniority := SeniorityMid
+	found := false
+	for i, word := range words {
+		level, ok := seniorityWords[word]
+		if i > 0 {
+			if phraseLevel, isPhrase := seniorityPhrases[words[i-1]+" "+word]; isPhrase && (!ok || phraseLevel > level) {
+				level, ok = phraseLevel, true
+			}
+		}
+		if ok && (!found || level > seniority) {
+			seniority = level
+			found = true
+		}
+	}
+	return seniority
+}
+
+//SeniorityRange represents the seniority levels a project looks for. Participants out of the range
+//are filtered out when Strict is set, and score less the further they are from it otherwise
+type SeniorityRange struct {
+	Min    Seniority `json:"min,omitempty"`
+	Max    Seniority `json:"max,omitempty"`
+	Strict bool      `json:"strict,omitempty"`
+}
+
+//distance returns how many levels the seniority is out of the range, 0 when it's in it
+func (r SeniorityRange) distance(seniority Seniority) int {
+	if r.Min != SeniorityUnknown && seniority < r.Min {
+		return int(r.Min - seniority)
+	}
+	if r.Max != SeniorityUnknown && seniority > r.Max {
+		return int(seniority - r.Max)
+	}
+	return 0
+}
+
+//Contains returns true when the seniority is known and in the range
+func (r SeniorityRange) Contains(seniority Seniority) bool {
+	return seniority != SeniorityUnknown && r.distance(seniority) == 0
+}
@@ -0,0 +1,65 @@
+package matching
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeniority(t *testing.T) {
+	t.Run("Given job titles, When seniority level is parsed, Then must return the level told by their words", func(t *testing.T) {
+		titles := map[string]Seniority{
+			"":                           SeniorityUnknown,
+			"Summer Intern":              SeniorityIntern,
+			"Jr. Java Developer":         SeniorityJunior,
+			"Software Engineer":          SeniorityMid,
+			"Software Engineer II":       SeniorityMid,
+			"Sr Software Engineer":       SenioritySenior,
+			"Principal Data Scientist":   SeniorityStaff,
+			"Tech Lead":                  SeniorityLead,
+			"Senior Engineering Manager": SeniorityManager,
+			"Head of Marketing":          SeniorityDirector,
+			"VP of Engineering":          SeniorityExecutive,
+			"Co-Founder & CTO":           SeniorityExecutive,
+			"Small Business Owner":       SeniorityExecutive,
+			"Product Owner":              SeniorityMid,
+			"Research Fellow":            SeniorityMid,
+			"Teaching Fellow":            SeniorityMid,
+			"Technical Fellow":           SeniorityStaff,
+		}
+
+		for title, expected := range titles {
+			assert.Equal(t, expected, ParseSeniorityLevel(title), title)
+		}
+	})
+	t.Run("Given a seniority name, When it's parsed, Then must return its level or an error when it's unknown", func(t *testing.T) {
+		seniority, err := ParseSeniority(" Staff ")
+		assert.Nil(t, err)
+		assert.Equal(t, SeniorityStaff, seniority)
+
+		_, err = ParseSeniority("guru")
+		assert.NotNil(t, err)
+	})
+	t.Run("Given a seniority range in JSON, When it's decoded and encoded, Then levels must be written by their names", func(t *testing.T) {
+		seniorityRange := SeniorityRange{}
+
+		err := json.Unmarshal([]byte(`{"min":"junior","max":"lead","strict":true}`), &seniorityRange)
+		assert.Nil(t, err)
+		assert.Equal(t, SeniorityRange{Min: SeniorityJunior, Max: SeniorityLead, Strict: true}, seniorityRange)
+
+		encoded, err := json.Marshal(seniorityRange)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"min":"junior","max":"lead","strict":true}`, string(encoded))
+
+		err = json.Unmarshal([]byte(`{"min":"guru"}`), &seniorityRange)
+		assert.NotNil(t, err)
+	})
+	t.Run("Given a seniority range, When seniorities are checked, Then must contain only known levels inside it", func(t *testing.T) {
+		seniorityRange := SeniorityRange{Min: SeniorityJunior}
+
+		assert.True(t, seniorityRange.Contains(SeniorityExecutive))
+		assert.False(t, seniorityRange.Contains(SeniorityIntern))
+		assert.False(t, seniorityRange.Contains(SeniorityUnknown))
+	})
+}
@@ -0,0 +1,154 @@
+package matching
+
+import (
+	"strings"
+	"unicode"
+)
+
+//DefaultJobTitleThreshold is the minimum similarity for a job title to score when the project doesn't set one
+const DefaultJobTitleThreshold = 0.8
+
+//minFuzzyTokenLength is the minimum length of the tokens compared by edit distance,
+//shorter ones like "qa" or "it" must be equal
+const minFuzzyTokenLength = 4
+
+//minTokenSimilarity is the minimum similarity for two tokens to be taken as the same word with a typo
+const minTokenSimilarity = 0.75
+
+//titleStopWords are ignored when job titles are compared
+var titleStopWords = map[string]bool{
+	"of":  true,
+	"and": true,
+	"the": true,
+	"for": true,
+	"in":  true,
+}
+
+//titleSeniorityWords are ignored when job titles are compared, seniority is scored on its own
+var titleSeniorityWords = map[string]bool{
+	"sr":         true,
+	"senior":     true,
+	"jr":         true,
+	"junior":     true,
+	"expert":     true,
+	"principal":  true,
+	"staff":      true,
+	"specialist": true,
+	"head":       true,
+	"lead":       true,
+}
+
+//TitleMatcher compares the job title of a participant with the one expected by a project
+type TitleMatcher interface {
+	//Similarity returns 1 when the participant title has every word of the expected one,
+	//in any order, and less the more words are missing or misspelled, down to 0
+	Similarity(expected string, actual string) float64
+}
+
+type titleMatcher struct {
+}
+
+func (m *titleMatcher) Similarity(expected string, actual string) float64 {
+	expectedTokens := normalizeTitle(expected)
+	actualTokens := normalizeTitle(actual)
+	if len(expectedTokens) == 0 || len(actualTokens) == 0 {
+		return 0
+	}
+
+	if strings.Join(expectedTokens, "") == strings.Join(actualTokens, "") {
+		//the same words written together or apart, like "Front-end" and "Frontend"
+		return 1
+	}
+	return tokenSetSimilarity(expectedTokens, actualTokens)
+}
+
+//normalizeTitle returns the lowercased words of a title, without punctuation, stop words or seniority words
+func normalizeTitle(title string) []string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := []string{}
+	for _, word := range words {
+		if !titleStopWords[word] && !titleSeniorityWords[word] {
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
+
+//tokenSetSimilarity returns the average similarity of every expected token with its closest actual token
+func tokenSetSimilarity(expected []string, actual []string) float64 {
+	total := 0.0
+	for _, expectedToken := range expected {
+		best := 0.0
+		for _, actualToken := range actual {
+			if similarity := tokenSimilarity(expectedToken, actualToken); similarity > best {
+				best = similarity
+			}
+		}
+		total += best
+	}
+	return total / float64(len(expected))
+}
+
+func tokenSimilarity(a string, b string) float64 {
+	if a == b {
+		return 1
+	}
+	if len(a) < minFuzzyTokenLength || len(b) < minFuzzyTokenLength {
+		return 0
+	}
+	if similarity := stringSimilarity(a, b); similarity >= minTokenSimilarity {
+		return similarity
+	}
+	return 0
+}
+
+//stringSimilarity returns 1 minus the edit distance between a and b relative to the longest of them
+func stringSimilarity(a string, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+//editDistance returns the Levenshtein distance between a and b
+func editDistance(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
+
+//NewTitleMatcher returns a TitleMatcher tolerant to typos, word order and punctuation
+func NewTitleMatcher() TitleMatcher {
+	return &titleMatcher{}
+}
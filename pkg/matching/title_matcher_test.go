@@ -0,0 +1,36 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTitleMatcher(t *testing.T) {
+	matcher := NewTitleMatcher()
+
+	t.Run("Given the same title in other case, order or punctuation, When similarity is evaluated, Then must be a full match", func(t *testing.T) {
+		assert.Equal(t, 1.0, matcher.Similarity("Software Engineer", "software engineer"))
+		assert.Equal(t, 1.0, matcher.Similarity("Software Engineer", "Engineer, Software"))
+		assert.Equal(t, 1.0, matcher.Similarity("Front-end Developer", "Frontend Developer"))
+	})
+	t.Run("Given a title with seniority or extra words, When similarity is evaluated, Then must be a full match", func(t *testing.T) {
+		assert.Equal(t, 1.0, matcher.Similarity("Software Engineer", "Senior Software Engineer"))
+		assert.Equal(t, 1.0, matcher.Similarity("Developer", "Java Developer"))
+	})
+	t.Run("Given a title with a typo, When similarity is evaluated, Then must be a partial match above the default threshold", func(t *testing.T) {
+		similarity := matcher.Similarity("Software Engineer", "Sofware Engineer")
+
+		assert.InDelta(t, 0.94, similarity, 0.01)
+		assert.True(t, similarity >= DefaultJobTitleThreshold)
+	})
+	t.Run("Given a title missing some of the expected words, When similarity is evaluated, Then must be the share of words found", func(t *testing.T) {
+		assert.InDelta(t, 0.67, matcher.Similarity("Java Software Engineer", "Software Engineer"), 0.01)
+	})
+	t.Run("Given unrelated or short similar words, When similarity is evaluated, Then must not match", func(t *testing.T) {
+		assert.Equal(t, 0.0, matcher.Similarity("Developer", "Accountant"))
+		assert.Equal(t, 0.0, matcher.Similarity("Java Developer", "Javascript Programmer"))
+		assert.Equal(t, 0.0, matcher.Similarity("QA", "IT"))
+		assert.Equal(t, 0.0, matcher.Similarity("", "Developer"))
+	})
+}
@@ -0,0 +1,87 @@
+package matching
+
+import (
+	"fmt"
+	"strings"
+)
+
+//ValidationError is retrived when a Project can't be used to look for participants
+type ValidationError struct {
+	Problems []string
+	//Query describes why the project query can't be parsed, nil when it can
+	Query *QueryError
+	//Subject is what was validated, a project when it's empty
+	Subject string
+}
+
+func (e ValidationError) Error() string {
+	subject := e.Subject
+	if subject == "" {
+		subject = "project"
+	}
+	return "Invalid " + subject + ": " + strings.Join(e.Problems, "; ")
+}
+
+//Validate returns a ValidationError describing every problem found in the Project, or nil when it's valid
+func (p Project) Validate() error {
+	problems := []string{}
+	if len(p.Cities) == 0 {
+		problems = append(problems, "at least one city is required")
+	}
+	ids, addresses := map[string]int{}, map[string]int{}
+	for i, city := range p.Cities {
+		address := strings.ToLower(strings.TrimSpace(city.CityLocation.FormattedAddress))
+		if address == "" {
+			problems = append(problems, fmt.Sprintf("cities[%d] formattedAddress is required", i))
+		}
+		//A repeated city would be matched and allocated twice, so cities must not share their ID or address
+		id := city.CityLocation.ID
+		first, repeated := ids[id]
+		if !repeated {
+			first, repeated = addresses[address]
+		}
+		if repeated {
+			problems = append(problems, fmt.Sprintf("cities[%d] repeats cities[%d]", i, first))
+		}
+		if _, ok := ids[id]; !ok && id != "" {
+			ids[id] = i
+		}
+		if _, ok := addresses[address]; !ok && address != "" {
+			addresses[address] = i
+		}
+		location := city.CityLocation.Location
+		if location.Latitude < -90 || location.Latitude > 90 {
+			problems = append(problems, fmt.Sprintf("cities[%d] latitude must be between -90 and 90", i))
+		}
+		if location.Longitude < -180 || location.Longitude > 180 {
+			problems = append(problems, fmt.Sprintf("cities[%d] longitude must be between -180 and 180", i))
+		}
+		if city.Capacity < 0 {
+			problems = append(problems, fmt.Sprintf("cities[%d] capacity must not be negative", i))
+		}
+	}
+	if p.JobTitleThreshold < 0 || p.JobTitleThreshold > 1 {
+		problems = append(problems, "jobTitleThreshold must be between 0 and 1")
+	}
+	if p.Seniority != nil && p.Seniority.Min != SeniorityUnknown && p.Seniority.Max != SeniorityUnknown && p.Seniority.Min > p.Seniority.Max {
+		problems = append(problems, "seniority min must not be above max")
+	}
+	if p.MinScore < 0 || p.MinScore > 1 {
+		problems = append(problems, "minScore must be between 0 and 1")
+	}
+	problems = append(problems, p.validateRequired()...)
+	problems = append(problems, p.validateQuotas()...)
+	problems = append(problems, p.validateFatigue()...)
+	var queryError *QueryError
+	if strings.TrimSpace(p.Query) != "" {
+		if _, err := ParseQuery(p.Query); err != nil {
+			queryError = err.(*QueryError)
+			problems = append(problems, "query: "+err.Error())
+		}
+	}
+
+	if len(problems) > 0 {
+		return ValidationError{Problems: problems, Query: queryError}
+	}
+	return nil
+}
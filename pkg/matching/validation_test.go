@@ -0,0 +1,108 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectValidation(t *testing.T) {
+	t.Run("Given a Project with a located city, When it's validated, Then must not return an error", func(t *testing.T) {
+		project := Project{
+			Cities: []City{
+				City{CityLocation: CityLocation{FormattedAddress: "New York, NY, USA", Location: Location{Latitude: 40.7127753, Longitude: -74.0059728}}},
+			},
+		}
+
+		assert.Nil(t, project.Validate())
+	})
+	t.Run("Given a Project without cities, When it's validated, Then must return a validation error", func(t *testing.T) {
+		err := Project{}.Validate()
+
+		assert.Equal(t, ValidationError{Problems: []string{"at least one city is required"}}, err)
+	})
+	t.Run("Given a Project with an incomplete city, When it's validated, Then must report every problem", func(t *testing.T) {
+		project := Project{
+			Cities: []City{
+				City{CityLocation: CityLocation{Location: Location{Latitude: 140, Longitude: -74.0059728}}},
+			},
+		}
+
+		err := project.Validate()
+
+		assert.Equal(t, "Invalid project: cities[0] formattedAddress is required; cities[0] latitude must be between -90 and 90", err.Error())
+	})
+	t.Run("Given a Project with repeated cities, When it's validated, Then must report every city repeating an ID or address", func(t *testing.T) {
+		project := Project{
+			Cities: []City{
+				City{CityLocation: CityLocation{ID: "ChIJOwg_06VPwokRYv534QaPC8g", FormattedAddress: "New York, NY, USA"}},
+				City{CityLocation: CityLocation{ID: "ChIJOwg_06VPwokRYv534QaPC8g", FormattedAddress: "New York City"}},
+				City{CityLocation: CityLocation{FormattedAddress: "Austin, TX, USA"}},
+				City{CityLocation: CityLocation{FormattedAddress: " austin, tx, usa"}},
+				City{CityLocation: CityLocation{ID: "ChIJLwPMoJm1RIYRetVp1EtGm10", FormattedAddress: "Austin, TX, USA"}},
+			},
+		}
+
+		err := project.Validate()
+
+		assert.Equal(t, "Invalid project: cities[1] repeats cities[0]; cities[3] repeats cities[2]; cities[4] repeats cities[2]", err.Error())
+	})
+	t.Run("Given a Project with a job title threshold out of range, When it's validated, Then must report it", func(t *testing.T) {
+		project := Project{
+			Cities:            []City{City{CityLocation: CityLocation{FormattedAddress: "New York, NY, USA"}}},
+			JobTitleThreshold: 1.5,
+		}
+
+		err := project.Validate()
+
+		assert.Equal(t, "Invalid project: jobTitleThreshold must be between 0 and 1", err.Error())
+	})
+	t.Run("Given a Project with a seniority range whose min is above its max, When it's validated, Then must report it", func(t *testing.T) {
+		project := Project{
+			Cities:    []City{City{CityLocation: CityLocation{FormattedAddress: "New York, NY, USA"}}},
+			Seniority: &SeniorityRange{Min: SeniorityDirector, Max: SeniorityJunior},
+		}
+
+		err := project.Validate()
+
+		assert.Equal(t, "Invalid project: seniority min must not be above max", err.Error())
+	})
+	t.Run("Given a Project with unknown or empty required criteria and a min score out of range, When it's validated, Then must report them", func(t *testing.T) {
+		project := Project{
+			Cities:   []City{City{CityLocation: CityLocation{FormattedAddress: "New York, NY, USA"}}},
+			Required: []Criterion{"salary", CriterionIndustry},
+			MinScore: 2,
+		}
+
+		err := project.Validate()
+
+		assert.Equal(t, `Invalid project: minScore must be between 0 and 1; required criterion "salary" is unknown; required criterion "industry" has no values`, err.Error())
+	})
+	t.Run("Given a Project with a query that can't be parsed, When it's validated, Then must report where it's wrong", func(t *testing.T) {
+		project := Project{
+			Cities: []City{City{CityLocation: CityLocation{FormattedAddress: "New York, NY, USA"}}},
+			Query:  "title:Java AND",
+		}
+
+		err := project.Validate()
+
+		assert.Equal(t, `Invalid project: query: expected field:value or "(", found end of query at position 15`, err.Error())
+		assert.Equal(t, &QueryError{Message: `expected field:value or "(", found end of query`, Position: 15}, err.(ValidationError).Query)
+	})
+	t.Run("Given a Project with invalid quotas, When it's validated, Then must report them", func(t *testing.T) {
+		project := Project{
+			Cities: []City{City{CityLocation: CityLocation{City: "New York", FormattedAddress: "New York, NY, USA"}}},
+			Quotas: []Quota{
+				{Field: "age", Value: "30", Min: 1},
+				{Field: QuotaCity, Value: "Miami", Min: 1},
+				{Field: QuotaGender, Value: "female", Min: 3, Max: 2},
+				{Field: QuotaCity, Value: "new york", Max: 2},
+			},
+			SelectionSize: -1,
+		}
+
+		err := project.Validate()
+
+		assert.Equal(t, `Invalid project: selectionSize must not be negative; quotas[0] field "age" is unknown, use one of city, gender, industry; quotas[1] city "Miami" is not a project city; quotas[2] min must not be above max`, err.Error())
+	})
+}